@@ -1,18 +1,74 @@
 package mailgun
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"net/url"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/mailgun/mailgun-go/v5"
+	"github.com/mailgun/mailgun-go/v5/mtypes"
 )
 
 // Config struct holds API key
 type Config struct {
-	APIKey        string
-	Region        string
-	MailgunClient *mailgun.Client
+	APIKey     string
+	Region     string
+	APIBaseURL string
+
+	// SubaccountID is sent as X-Mailgun-On-Behalf-Of unless a resource
+	// overrides it.
+	SubaccountID string
+
+	// HTTPProxy, CACertPEM, InsecureSkipVerify and RequestTimeout configure
+	// the transport used to reach Mailgun.
+	HTTPProxy          string
+	CACertPEM          string
+	InsecureSkipVerify bool
+	RequestTimeout     time.Duration
+
+	MaxRetries   int
+	RetryMinWait time.Duration
+	RetryMaxWait time.Duration
+
+	// RequestsPerSecond limits the API calls made through all clients. Zero
+	// disables the limit.
+	RequestsPerSecond float64
+	Burst             int
+
+	// Accounts holds the named accounts configured in the provider accounts
+	// block. Resources select one with their account attribute.
+	Accounts map[string]Account
+
+	// accountName is the name of the accounts entry this Config was derived
+	// from, empty for the provider's own credentials.
+	accountName string
+
+	// clients caches one Mailgun client per region and subaccount. Clients are shared by
+	// resources running in parallel and must not be mutated once cached.
+	clientsMu      sync.Mutex
+	clients        map[string]*mailgun.Client
+	accountConfigs map[string]*Config
+
+	// httpClient is shared by every cached client so that all API calls go
+	// through the same transport.
+	httpClient *http.Client
+}
+
+// Account holds the credentials of one entry of the provider accounts block.
+type Account struct {
+	APIKey       string
+	Region       string
+	SubaccountID string
 }
 
 // Client returns a new client for accessing mailgun.
@@ -23,20 +79,295 @@ func (c *Config) Client() (*Config, diag.Diagnostics) {
 	return c, nil
 }
 
-// GetClient returns a client based on region.
+// ValidateCredentials makes a cheap authenticated call with the configured
+// API key so that a wrong key, missing permissions or an unreachable API are
+// reported once, at provider level, instead of inside the first resource.
+func (c *Config) ValidateCredentials(ctx context.Context) diag.Diagnostics {
+	client, err := c.GetClient("")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	source := "the provider api_key or the MAILGUN_API_KEY environment variable"
+	if c.accountName != "" {
+		source = fmt.Sprintf("the api_key of account %q", c.accountName)
+	}
+
+	var page []mtypes.Domain
+	it := client.ListDomains(&mailgun.ListDomainsOptions{Limit: 1})
+	if it.First(ctx, &page) {
+		log.Printf("[DEBUG] Mailgun credentials validated against %s", client.APIBase())
+		return nil
+	}
+
+	err = it.Err()
+
+	switch mailgun.GetStatusFromErr(err) {
+	case http.StatusUnauthorized:
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid Mailgun API key",
+			Detail:        fmt.Sprintf("Mailgun at %s rejected the configured API key (401 Unauthorized). Check %s.", client.APIBase(), source),
+			AttributePath: cty.GetAttrPath("api_key"),
+		}}
+	case http.StatusForbidden:
+		detail := fmt.Sprintf("Mailgun at %s accepted %s but denied access (403 Forbidden). The key may lack the required role", client.APIBase(), source)
+		if c.SubaccountID != "" {
+			detail += fmt.Sprintf(", or may not be allowed to act on behalf of subaccount %q", c.SubaccountID)
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Mailgun API key is not authorized",
+			Detail:   detail + ".",
+		}}
+	case -1:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to reach the Mailgun API",
+			Detail:   fmt.Sprintf("Validating credentials against %s failed: %s", client.APIBase(), err),
+		}}
+	}
+
+	return diag.Errorf("Error validating Mailgun credentials: %s", err)
+}
+
+// Account returns the configuration of the named entry of the provider
+// accounts block. An empty name returns the provider's own configuration. The
+// returned Config shares the HTTP client, and therefore the retry and rate
+// limit settings, with the provider.
+func (c *Config) Account(name string) (*Config, error) {
+	if name == "" {
+		return c, nil
+	}
+
+	account, ok := c.Accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("account %q is not configured in the provider accounts block", name)
+	}
+
+	c.clientsMu.Lock()
+	defer c.clientsMu.Unlock()
+
+	if config, ok := c.accountConfigs[name]; ok {
+		return config, nil
+	}
+
+	httpClient, err := c.sharedHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	region := account.Region
+	if region == "" {
+		region = c.Region
+	}
+
+	config := &Config{
+		APIKey:       account.APIKey,
+		Region:       region,
+		APIBaseURL:   c.APIBaseURL,
+		SubaccountID: account.SubaccountID,
+		accountName:  name,
+		httpClient:   httpClient,
+	}
+
+	if c.accountConfigs == nil {
+		c.accountConfigs = make(map[string]*Config)
+	}
+	c.accountConfigs[name] = config
+
+	return config, nil
+}
+
+// GetClient returns a client based on region. An empty region falls back to
+// the region configured on the provider. Requests are sent on behalf of the
+// provider subaccount_id, if any.
 func (c *Config) GetClient(Region string) (*mailgun.Client, error) {
+	return c.getClient(Region, c.SubaccountID)
+}
 
-	c.MailgunClient = mailgun.NewMailgun(c.APIKey)
-	c.Region = Region
-	c.ConfigureBaseUrl(Region)
+// GetSubaccountClient returns a client for region that sends every request on
+// behalf of subaccountID. An empty subaccountID means the primary account:
+// resources store the provider subaccount_id when they are created or
+// imported, and later changes to it must not move them to another account.
+// Clients are built lazily and reused for every later call with the same
+// region and subaccount.
+func (c *Config) GetSubaccountClient(Region, subaccountID string) (*mailgun.Client, error) {
+	return c.getClient(Region, subaccountID)
+}
 
-	return c.MailgunClient, nil
+// GetAccountClient returns a client for region that acts on the primary
+// account itself, ignoring the provider subaccount_id. Subaccounts are
+// managed through this client.
+func (c *Config) GetAccountClient(Region string) (*mailgun.Client, error) {
+	return c.getClient(Region, "")
 }
 
-func (c *Config) ConfigureBaseUrl(Region string) {
+func (c *Config) getClient(Region, subaccountID string) (*mailgun.Client, error) {
+	region := c.normalizeRegion(Region)
+	key := region + "/" + subaccountID
+
+	c.clientsMu.Lock()
+	defer c.clientsMu.Unlock()
+
+	if client, ok := c.clients[key]; ok {
+		return client, nil
+	}
+
+	if c.APIKey == "" && len(c.Accounts) > 0 {
+		return nil, fmt.Errorf("no api_key is configured for the provider, set the account attribute to use one of the provider accounts")
+	}
+
+	httpClient, err := c.sharedHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	client := mailgun.NewMailgun(c.APIKey)
+	client.SetHTTPClient(httpClient)
+
+	if subaccountID != "" {
+		// The SDK only applies its on-behalf-of override to messages, so
+		// the header is added by the transport instead.
+		client.SetHTTPClient(&http.Client{
+			Transport: &onBehalfOfTransport{
+				next:         httpClient.Transport,
+				subaccountID: subaccountID,
+			},
+		})
+	}
+
+	if err := client.SetAPIBase(c.apiBase(region)); err != nil {
+		return nil, fmt.Errorf("invalid api_base_url %q: %w", c.APIBaseURL, err)
+	}
+
+	if c.clients == nil {
+		c.clients = make(map[string]*mailgun.Client)
+	}
+	c.clients[key] = client
+
+	log.Printf("[DEBUG] Mailgun client for region %q and subaccount %q uses %s", region, subaccountID, client.APIBase())
+
+	return client, nil
+}
+
+// sharedHTTPClient returns the HTTP client shared by every client built from
+// this Config, creating it on first use. c.clientsMu must be held.
+func (c *Config) sharedHTTPClient() (*http.Client, error) {
+	if c.httpClient == nil {
+		httpClient, err := c.newHTTPClient()
+		if err != nil {
+			return nil, err
+		}
+		c.httpClient = httpClient
+	}
+
+	return c.httpClient, nil
+}
+
+// newHTTPClient builds the HTTP client used for all Mailgun API calls.
+func (c *Config) newHTTPClient() (*http.Client, error) {
+	base, err := c.newBaseTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	var transport http.RoundTripper = base
+
+	transport = &loggingTransport{next: transport}
+
+	if c.RequestTimeout > 0 {
+		transport = &timeoutTransport{next: transport, timeout: c.RequestTimeout}
+	}
+
+	if c.RequestsPerSecond > 0 {
+		transport = &rateLimitTransport{
+			next:    transport,
+			limiter: newRateLimiter(c.RequestsPerSecond, c.Burst),
+		}
+	}
+
+	transport = &retryTransport{
+		next:       transport,
+		maxRetries: c.MaxRetries,
+		minWait:    c.RetryMinWait,
+		maxWait:    c.RetryMaxWait,
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// newBaseTransport returns the transport that talks to the network, honoring
+// the proxy, TLS and timeout settings of the provider.
+func (c *Config) newBaseTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if c.HTTPProxy != "" {
+		proxyURL, err := url.Parse(c.HTTPProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid http_proxy %q: %w", c.HTTPProxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if c.CACertPEM != "" || c.InsecureSkipVerify {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec // explicitly requested by the user
+		}
+
+		if c.CACertPEM != "" {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				log.Printf("[WARN] Unable to load the system certificate pool, only trusting the configured CA: %s", err)
+				pool = x509.NewCertPool()
+			}
+
+			if !pool.AppendCertsFromPEM([]byte(c.CACertPEM)) {
+				return nil, fmt.Errorf("no valid PEM certificates found in the configured CA bundle")
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	if c.RequestTimeout > 0 {
+		transport.DialContext = (&net.Dialer{
+			Timeout:   c.RequestTimeout,
+			KeepAlive: 30 * time.Second,
+		}).DialContext
+		transport.TLSHandshakeTimeout = c.RequestTimeout
+		transport.ResponseHeaderTimeout = c.RequestTimeout
+	}
+
+	return transport, nil
+}
+
+// normalizeRegion maps a resource region onto the key used for the client
+// cache. Anything that isn't "eu" is served by the US endpoint.
+func (c *Config) normalizeRegion(Region string) string {
+	if Region == "" {
+		Region = c.Region
+	}
+
 	if strings.ToLower(Region) == "eu" {
-		_ = c.MailgunClient.SetAPIBase(mailgun.APIBaseEU)
-	} else {
-		_ = c.MailgunClient.SetAPIBase(mailgun.APIBase)
+		return "eu"
 	}
+
+	return "us"
+}
+
+// apiBase returns the API base URL for the region, unless the provider
+// overrides it with api_base_url.
+func (c *Config) apiBase(region string) string {
+	if c.APIBaseURL != "" {
+		return strings.TrimSuffix(c.APIBaseURL, "/")
+	}
+
+	if region == "eu" {
+		return mailgun.APIBaseEU
+	}
+
+	return mailgun.APIBase
 }
@@ -0,0 +1,336 @@
+package mailgun
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mailgun/mailgun-go/v5"
+)
+
+func TestConfigGetClient_APIBase(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		config   *Config
+		region   string
+		expected string
+	}{
+		{"explicit us", &Config{Region: "eu"}, "us", mailgun.APIBase},
+		{"explicit eu", &Config{Region: "us"}, "eu", mailgun.APIBaseEU},
+		{"provider default", &Config{Region: "eu"}, "", mailgun.APIBaseEU},
+		{"base url override", &Config{Region: "eu", APIBaseURL: "http://localhost:8080/"}, "us", "http://localhost:8080"},
+	}
+
+	for _, tc := range cases {
+		client, err := tc.config.GetClient(tc.region)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+
+		if client.APIBase() != tc.expected {
+			t.Fatalf("%s: expected API base %q, got %q", tc.name, tc.expected, client.APIBase())
+		}
+	}
+}
+
+func TestConfigGetClient_InvalidAPIBase(t *testing.T) {
+	t.Parallel()
+
+	config := Config{APIBaseURL: "https://proxy.example.com/v3"}
+
+	if _, err := config.GetClient("us"); err == nil {
+		t.Fatal("expected an error for an API base URL containing a version")
+	}
+}
+
+func TestConfigGetClient_Cache(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{APIKey: "key", Region: "us"}
+
+	var wg sync.WaitGroup
+	clients := make([]*mailgun.Client, 20)
+
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			region := "us"
+			if i%2 == 0 {
+				region = "eu"
+			}
+
+			client, err := config.GetClient(region)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			clients[i] = client
+		}(i)
+	}
+	wg.Wait()
+
+	for i, client := range clients {
+		expected := mailgun.APIBase
+		if i%2 == 0 {
+			expected = mailgun.APIBaseEU
+		}
+
+		if client.APIBase() != expected {
+			t.Fatalf("client %d: expected API base %q, got %q", i, expected, client.APIBase())
+		}
+
+		if client != clients[i%2] {
+			t.Fatalf("client %d: expected the cached client to be reused", i)
+		}
+	}
+
+	defaultClient, _ := config.GetClient("")
+	if defaultClient != clients[1] {
+		t.Fatal("expected the provider region to share the us client")
+	}
+}
+
+func TestConfigGetSubaccountClient_OnBehalfOf(t *testing.T) {
+	t.Parallel()
+
+	headers := make(chan string, 4)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Get(mailgun.OnBehalfOfHeader)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"route": {"id": "route-id"}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{APIKey: "key", Region: "us", APIBaseURL: server.URL, SubaccountID: "provider-sub"}
+
+	cases := []struct {
+		subaccountID string
+		expected     string
+	}{
+		{"", ""},
+		{"resource-sub", "resource-sub"},
+	}
+
+	for _, tc := range cases {
+		client, err := config.GetSubaccountClient("us", tc.subaccountID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := client.GetRoute(context.Background(), "route-id"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if header := <-headers; header != tc.expected {
+			t.Fatalf("expected on-behalf-of %q, got %q", tc.expected, header)
+		}
+	}
+
+	client, _ := config.GetClient("us")
+	if _, err := client.GetRoute(context.Background(), "route-id"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if header := <-headers; header != "provider-sub" {
+		t.Fatalf("expected on-behalf-of %q, got %q", "provider-sub", header)
+	}
+
+	parent := &Config{APIKey: "key", Region: "us", APIBaseURL: server.URL}
+	client, _ = parent.GetClient("us")
+	if _, err := client.GetRoute(context.Background(), "route-id"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if header := <-headers; header != "" {
+		t.Fatalf("expected no on-behalf-of header, got %q", header)
+	}
+}
+
+func TestConfigAccount(t *testing.T) {
+	t.Parallel()
+
+	type request struct{ apiKey, subaccountID string }
+
+	requests := make(chan request, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, apiKey, _ := r.BasicAuth()
+		requests <- request{apiKey, r.Header.Get(mailgun.OnBehalfOfHeader)}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"route": {"id": "route-id"}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIKey:     "provider-key",
+		Region:     "us",
+		APIBaseURL: server.URL,
+		Accounts: map[string]Account{
+			"prod":      {APIKey: "prod-key", Region: "eu"},
+			"marketing": {APIKey: "marketing-key", SubaccountID: "marketing-sub"},
+		},
+	}
+
+	if _, err := config.Account("staging"); err == nil {
+		t.Fatal("expected an error for an unknown account")
+	}
+
+	if self, _ := config.Account(""); self != config {
+		t.Fatal("expected an empty account name to return the provider configuration")
+	}
+
+	prod, err := config.Account("prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if prod.Region != "eu" {
+		t.Fatalf("expected the account region eu, got %q", prod.Region)
+	}
+	if again, _ := config.Account("prod"); again != prod {
+		t.Fatal("expected the account configuration to be cached")
+	}
+
+	marketing, _ := config.Account("marketing")
+	if marketing.Region != "us" {
+		t.Fatalf("expected the account to default to the provider region, got %q", marketing.Region)
+	}
+	if marketing.httpClient != config.httpClient {
+		t.Fatal("expected accounts to share the provider HTTP client")
+	}
+
+	cases := []struct {
+		config   *Config
+		expected request
+	}{
+		{prod, request{"prod-key", ""}},
+		{marketing, request{"marketing-key", "marketing-sub"}},
+	}
+
+	for _, tc := range cases {
+		client, err := tc.config.GetClient("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := client.GetRoute(context.Background(), "route-id"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if req := <-requests; req != tc.expected {
+			t.Fatalf("expected %+v, got %+v", tc.expected, req)
+		}
+	}
+}
+
+func TestConfigValidateCredentials(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		status  int
+		summary string
+	}{
+		{http.StatusOK, ""},
+		{http.StatusUnauthorized, "Invalid Mailgun API key"},
+		{http.StatusForbidden, "Mailgun API key is not authorized"},
+	}
+
+	for _, tc := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(tc.status)
+			_, _ = w.Write([]byte(`{"total_count": 0, "items": []}`))
+		}))
+
+		config := &Config{APIKey: "key", Region: "us", APIBaseURL: server.URL}
+		diags := config.ValidateCredentials(context.Background())
+		server.Close()
+
+		if tc.summary == "" {
+			if diags.HasError() {
+				t.Fatalf("status %d: unexpected diagnostics: %#v", tc.status, diags)
+			}
+			continue
+		}
+
+		if !diags.HasError() || diags[0].Summary != tc.summary {
+			t.Fatalf("status %d: expected %q, got %#v", tc.status, tc.summary, diags)
+		}
+	}
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	config := &Config{APIKey: "key", Region: "us", APIBaseURL: server.URL}
+	diags := config.ValidateCredentials(context.Background())
+
+	if !diags.HasError() || diags[0].Summary != "Unable to reach the Mailgun API" {
+		t.Fatalf("expected a network error, got %#v", diags)
+	}
+}
+
+func TestConfigGetClient_CACertPEM(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"route": {"id": "route-id"}}`))
+	}))
+	defer server.Close()
+
+	caCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	untrusted := &Config{APIKey: "key", APIBaseURL: server.URL}
+	client, err := untrusted.GetClient("us")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.GetRoute(context.Background(), "route-id"); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected")
+	}
+
+	for _, config := range []*Config{
+		{APIKey: "key", APIBaseURL: server.URL, CACertPEM: caCertPEM},
+		{APIKey: "key", APIBaseURL: server.URL, InsecureSkipVerify: true},
+	} {
+		client, err := config.GetClient("us")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := client.GetRoute(context.Background(), "route-id"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	invalid := &Config{APIKey: "key", CACertPEM: "not a certificate"}
+	if _, err := invalid.GetClient("us"); err == nil {
+		t.Fatal("expected an error for an invalid CA bundle")
+	}
+}
+
+func TestConfigNewBaseTransport(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{HTTPProxy: "http://proxy.example.com:3128", RequestTimeout: 15 * time.Second}
+
+	transport, err := config.newBaseTransport()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.mailgun.net/v3/domains", nil)
+	proxyURL, err := transport.Proxy(req)
+	if err != nil || proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" {
+		t.Fatalf("expected requests to use the configured proxy, got %v (%v)", proxyURL, err)
+	}
+
+	if transport.ResponseHeaderTimeout != 15*time.Second {
+		t.Fatalf("expected a 15s response timeout, got %s", transport.ResponseHeaderTimeout)
+	}
+}
@@ -1,6 +1,9 @@
 package mailgun
 
 import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -8,24 +11,31 @@ func dataSourceMailgunDomain() *schema.Resource {
 
 	mailgunSchema := resourceMailgunDomain()
 
+	// Write-only attributes only exist on managed resources.
+	delete(mailgunSchema.Schema, "smtp_password_wo")
+	mailgunSchema.Schema["smtp_password"].ConflictsWith = nil
+
 	return &schema.Resource{
-		Read:   dataSourceMailgunDomainRead,
-		Schema: mailgunSchema.Schema,
+		ReadContext: dataSourceMailgunDomainRead,
+		Schema:      mailgunSchema.Schema,
 	}
 }
 
-func dataSourceMailgunDomainRead(d *schema.ResourceData, meta interface{}) error {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+func dataSourceMailgunDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
-		return errc
+		return diag.FromErr(errc)
 	}
 
 	name := d.Get("name").(string)
 
-	_, err := resourceMailgunDomainRetrieve(name, client, d)
+	_, err := resourceMailgunDomainRetrieve(ctx, name, client, d)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(name)
@@ -0,0 +1,122 @@
+package mailgun
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mailgun/mailgun-go/v5"
+	"github.com/mailgun/mailgun-go/v5/mtypes"
+)
+
+func dataSourceMailgunIP() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMailgunIPRead,
+		Schema: map[string]*schema.Schema{
+			"ip": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"account": dataSourceAccountSchema("the IP"),
+
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"subaccount_id": dataSourceSubaccountSchema("the IP"),
+
+			"rdns": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dedicated": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"assignable_to_pools": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"is_on_warmup": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"domains": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceMailgunIPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	address := d.Get("ip").(string)
+
+	ip, err := client.GetIP(ctx, address)
+	if err != nil {
+		return diag.Errorf("Error retrieving IP %s: %s", address, err)
+	}
+
+	_ = d.Set("rdns", ip.RDNS)
+	_ = d.Set("dedicated", ip.Dedicated)
+
+	// Only the IP list reports the warmup and pool status.
+	ips, err := client.ListIPs(ctx, false, false)
+	if err != nil {
+		return diag.Errorf("Error listing IPs: %s", err)
+	}
+
+	for _, i := range ips {
+		if i.IP == address {
+			_ = d.Set("assignable_to_pools", i.AssignableToPools)
+			_ = d.Set("is_on_warmup", i.IsOnWarmup)
+		}
+	}
+
+	domains, err := listIPDomains(ctx, client, address)
+	if err != nil {
+		return diag.Errorf("Error listing domains of IP %s: %s", address, err)
+	}
+
+	_ = d.Set("domains", domains)
+
+	d.SetId(address)
+
+	return nil
+}
+
+// listIPDomains returns the names of the domains an IP is assigned to.
+func listIPDomains(ctx context.Context, client *mailgun.Client, ip string) ([]string, error) {
+	it := client.ListIPDomains(ip, &mailgun.ListIPDomainOptions{Limit: 100})
+
+	var page []mtypes.DomainIPs
+	domains := []string{}
+
+	for it.Next(ctx, &page) {
+		for _, domain := range page {
+			domains = append(domains, domain.Domain)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return domains, nil
+}
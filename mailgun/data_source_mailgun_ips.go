@@ -0,0 +1,121 @@
+package mailgun
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	ipTypeAll       = "all"
+	ipTypeDedicated = "dedicated"
+	ipTypeShared    = "shared"
+)
+
+func dataSourceMailgunIPs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMailgunIPsRead,
+		Schema: map[string]*schema.Schema{
+			"account": dataSourceAccountSchema("the IPs"),
+
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"subaccount_id": dataSourceSubaccountSchema("the IPs"),
+
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      ipTypeAll,
+				ValidateFunc: validation.StringInSlice([]string{ipTypeAll, ipTypeDedicated, ipTypeShared}, false),
+				Description:  "Only return `dedicated` or `shared` IPs.",
+			},
+
+			"ips": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"ip_addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"assignable_to_pools": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"is_on_warmup": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMailgunIPsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	ipType := d.Get("type").(string)
+
+	ips, err := client.ListIPs(ctx, ipType == ipTypeDedicated, false)
+	if err != nil {
+		return diag.Errorf("Error listing IPs: %s", err)
+	}
+
+	// The API can only filter on dedicated IPs, so shared IPs are the ones
+	// missing from that list.
+	dedicated := map[string]bool{}
+	if ipType == ipTypeShared {
+		dedicatedIPs, err := client.ListIPs(ctx, true, false)
+		if err != nil {
+			return diag.Errorf("Error listing dedicated IPs: %s", err)
+		}
+
+		for _, ip := range dedicatedIPs {
+			dedicated[ip.IP] = true
+		}
+	}
+
+	ipList := []string{}
+	ipAddresses := []map[string]interface{}{}
+
+	for _, ip := range ips {
+		if dedicated[ip.IP] {
+			continue
+		}
+
+		ipList = append(ipList, ip.IP)
+		ipAddresses = append(ipAddresses, map[string]interface{}{
+			"ip":                  ip.IP,
+			"assignable_to_pools": ip.AssignableToPools,
+			"is_on_warmup":        ip.IsOnWarmup,
+		})
+	}
+
+	_ = d.Set("ips", ipList)
+	_ = d.Set("ip_addresses", ipAddresses)
+
+	d.SetId(d.Get("region").(string) + ":" + ipType)
+
+	return nil
+}
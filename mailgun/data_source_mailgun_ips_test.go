@@ -0,0 +1,93 @@
+package mailgun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mailgun/mailgun-go/v5"
+)
+
+func TestAccMailgunIPsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMailgunIPsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.mailgun_ips.all", "ips.#"),
+					resource.TestCheckResourceAttrPair("data.mailgun_ip.first", "ip", "data.mailgun_ips.all", "ips.0"),
+					resource.TestCheckResourceAttrSet("data.mailgun_ip.first", "domains.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceMailgunIPsRead_Shared(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path != "/v3/ips" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Query().Get("dedicated") == "true" {
+			_, _ = w.Write([]byte(`{"items": ["10.0.0.2"], "details": [{"ip": "10.0.0.2", "is_on_warmup": true}], "assignable_to_pools": ["10.0.0.2"]}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"items": ["10.0.0.1", "10.0.0.2"], "details": [{"ip": "10.0.0.1"}, {"ip": "10.0.0.2", "is_on_warmup": true}], "assignable_to_pools": ["10.0.0.2"]}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceMailgunIPs().Schema, map[string]interface{}{
+		"type": ipTypeShared,
+	})
+
+	diags := dataSourceMailgunIPsRead(context.Background(), d, &Config{APIKey: "key", APIBaseURL: server.URL})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if ips := d.Get("ips").([]interface{}); !reflect.DeepEqual(ips, []interface{}{"10.0.0.1"}) {
+		t.Fatalf("expected only the shared IP, got %v", ips)
+	}
+}
+
+func TestDataSourceMailgunIPsRead_Subaccount(t *testing.T) {
+	subaccounts := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subaccounts <- r.Header.Get(mailgun.OnBehalfOfHeader)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"items": ["10.0.0.1"], "details": [{"ip": "10.0.0.1"}]}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceMailgunIPs().Schema, map[string]interface{}{
+		"subaccount_id": "ips-sub",
+	})
+
+	diags := dataSourceMailgunIPsRead(context.Background(), d, &Config{APIKey: "key", APIBaseURL: server.URL, SubaccountID: "provider-sub"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if subaccount := <-subaccounts; subaccount != "ips-sub" {
+		t.Fatalf("expected the IPs to be listed on behalf of ips-sub, got %q", subaccount)
+	}
+}
+
+const testAccMailgunIPsDataSourceConfig = `
+data "mailgun_ips" "all" {}
+
+data "mailgun_ip" "first" {
+	ip = data.mailgun_ips.all.ips[0]
+}
+`
@@ -0,0 +1,102 @@
+package mailgun
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mailgun/mailgun-go/v5"
+	"github.com/mailgun/mailgun-go/v5/mtypes"
+)
+
+func dataSourceMailgunSubaccounts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMailgunSubaccountsRead,
+		Schema: map[string]*schema.Schema{
+			"account": dataSourceAccountSchema("the subaccounts"),
+
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"enabled_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return subaccounts that are enabled.",
+			},
+
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"subaccounts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceMailgunSubaccountsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	setDefaultRegion(d, meta)
+
+	client, errc := accountClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	enabledOnly := d.Get("enabled_only").(bool)
+
+	it := client.ListSubaccounts(&mailgun.ListSubaccountsOptions{Limit: 100})
+
+	var page []mtypes.Subaccount
+	ids := []string{}
+	subaccounts := []map[string]interface{}{}
+
+	for it.Next(ctx, &page) {
+		for _, s := range page {
+			if enabledOnly && s.Status == subaccountStatusDisabled {
+				continue
+			}
+
+			ids = append(ids, s.ID)
+			subaccounts = append(subaccounts, map[string]interface{}{
+				"id":     s.ID,
+				"name":   s.Name,
+				"status": s.Status,
+			})
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("ids", ids)
+	_ = d.Set("subaccounts", subaccounts)
+
+	d.SetId(d.Get("region").(string))
+
+	return nil
+}
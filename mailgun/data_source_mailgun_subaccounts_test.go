@@ -0,0 +1,48 @@
+package mailgun
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMailgunSubaccountsDataSource_Basic(t *testing.T) {
+	uuid, _ := uuid.GenerateUUID()
+	name := fmt.Sprintf("terraform-%s", uuid[:8])
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunSubaccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMailgunSubaccountsDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.mailgun_subaccounts.test", "subaccounts.*", map[string]string{
+							"name":   name,
+							"status": "enabled",
+						}),
+					resource.TestCheckTypeSetElemAttrPair(
+						"data.mailgun_subaccounts.test", "ids.*", "mailgun_subaccount.foobar", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMailgunSubaccountsDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "mailgun_subaccount" "foobar" {
+	name = "%s"
+}
+
+data "mailgun_subaccounts" "test" {
+	enabled_only = true
+
+	depends_on = [mailgun_subaccount.foobar]
+}
+`, name)
+}
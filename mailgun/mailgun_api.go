@@ -0,0 +1,402 @@
+package mailgun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mailgun/mailgun-go/v5"
+	"github.com/mailgun/mailgun-go/v5/mtypes"
+)
+
+// apiRequest calls a Mailgun endpoint that mailgun-go doesn't cover. It goes
+// through the client's HTTP client, so retries, rate limiting, logging and the
+// on-behalf-of header apply as for SDK calls. path is relative to the API
+// base, for example "/v4/domains/example.com". form, if not nil, is sent
+// URL-encoded and a JSON response is decoded into out, if not nil.
+//
+// Unsuccessful responses are returned as *mailgun.UnexpectedResponseError so
+// that isNotFound and mailgun.GetStatusFromErr work on them.
+func apiRequest(ctx context.Context, client *mailgun.Client, method, path string, form url.Values, out interface{}) error {
+	endpoint := client.APIBase() + path
+
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return err
+	}
+
+	req.SetBasicAuth("api", client.APIKey())
+	req.Header.Set("Accept", "application/json")
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := client.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &mailgun.UnexpectedResponseError{
+			Expected: []int{http.StatusOK},
+			Actual:   resp.StatusCode,
+			Method:   method,
+			URL:      endpoint,
+			Data:     data,
+		}
+	}
+
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("decoding response of %s %s: %w", method, path, err)
+		}
+	}
+
+	return nil
+}
+
+// dkimKey is a DKIM key as returned by the domain keys API.
+type dkimKey struct {
+	SigningDomain string           `json:"signing_domain"`
+	Selector      string           `json:"selector"`
+	DNSRecord     mtypes.DNSRecord `json:"dns_record"`
+}
+
+// createDKIMKey creates a DKIM key for signingDomain. Mailgun generates a key
+// of the given size unless a PEM encoded private key is passed.
+func createDKIMKey(ctx context.Context, client *mailgun.Client, signingDomain, selector string, bits int, pem string) (*dkimKey, error) {
+	form := url.Values{
+		"signing_domain": {signingDomain},
+		"selector":       {selector},
+	}
+
+	if pem != "" {
+		form.Set("pem", pem)
+	} else if bits > 0 {
+		form.Set("bits", strconv.Itoa(bits))
+	}
+
+	var key dkimKey
+	if err := apiRequest(ctx, client, http.MethodPost, "/v1/dkim/keys", form, &key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// listDomainKeys returns the DKIM keys of a domain.
+func listDomainKeys(ctx context.Context, client *mailgun.Client, domain string) ([]dkimKey, error) {
+	var resp struct {
+		Items []dkimKey `json:"items"`
+	}
+
+	if err := apiRequest(ctx, client, http.MethodGet, "/v4/domains/"+url.PathEscape(domain)+"/keys", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Items, nil
+}
+
+// getDomainKey returns the DKIM key of a domain with the given selector.
+func getDomainKey(ctx context.Context, client *mailgun.Client, domain, selector string) (*dkimKey, error) {
+	keys, err := listDomainKeys(ctx, client, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range keys {
+		if keys[i].Selector == selector {
+			return &keys[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("DKIM key %s of domain %s %w", selector, domain, errNotFound)
+}
+
+// activateDomainKey starts signing the messages of domain with the key.
+func activateDomainKey(ctx context.Context, client *mailgun.Client, domain, selector string) error {
+	return apiRequest(ctx, client, http.MethodPut, "/v4/domains/"+url.PathEscape(domain)+"/keys/"+url.PathEscape(selector)+"/activate", nil, nil)
+}
+
+// deactivateDomainKey stops signing the messages of domain with the key.
+func deactivateDomainKey(ctx context.Context, client *mailgun.Client, domain, selector string) error {
+	return apiRequest(ctx, client, http.MethodPut, "/v4/domains/"+url.PathEscape(domain)+"/keys/"+url.PathEscape(selector)+"/deactivate", nil, nil)
+}
+
+// deleteDKIMKey deletes a DKIM key.
+func deleteDKIMKey(ctx context.Context, client *mailgun.Client, signingDomain, selector string) error {
+	query := url.Values{
+		"signing_domain": {signingDomain},
+		"selector":       {selector},
+	}
+
+	return apiRequest(ctx, client, http.MethodDelete, "/v1/dkim/keys?"+query.Encode(), nil, nil)
+}
+
+// getDomain is client.GetDomain that also returns the ID of the IP pool
+// linked to the domain, which mtypes.Domain lacks. The ID is empty if the
+// domain doesn't send from a pool.
+func getDomain(ctx context.Context, client *mailgun.Client, name string) (*mtypes.GetDomainResponse, string, error) {
+	var data json.RawMessage
+
+	if err := apiRequest(ctx, client, http.MethodGet, "/v4/domains/"+url.PathEscape(name), nil, &data); err != nil {
+		return nil, "", err
+	}
+
+	var resp mtypes.GetDomainResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, "", fmt.Errorf("decoding domain %s: %w", name, err)
+	}
+
+	var pool struct {
+		Domain struct {
+			PoolID string `json:"pool_id"`
+		} `json:"domain"`
+	}
+	if err := json.Unmarshal(data, &pool); err != nil {
+		return nil, "", fmt.Errorf("decoding domain %s: %w", name, err)
+	}
+
+	return &resp, pool.Domain.PoolID, nil
+}
+
+// linkDomainIPPool makes a domain send from the IPs of a pool.
+func linkDomainIPPool(ctx context.Context, client *mailgun.Client, domain, poolID string) error {
+	form := url.Values{"pool_id": {poolID}}
+
+	return apiRequest(ctx, client, http.MethodPost, "/v3/domains/"+url.PathEscape(domain)+"/ips", form, nil)
+}
+
+// unlinkDomainIPPool unlinks the IP pool of a domain. If replacementPoolID
+// isn't empty, that pool is linked in its place.
+func unlinkDomainIPPool(ctx context.Context, client *mailgun.Client, domain, replacementPoolID string) error {
+	path := "/v3/domains/" + url.PathEscape(domain) + "/ips/ip_pool"
+	if replacementPoolID != "" {
+		path += "?" + url.Values{"pool_id": {replacementPoolID}}.Encode()
+	}
+
+	return apiRequest(ctx, client, http.MethodDelete, path, nil, nil)
+}
+
+// ipPool is a dedicated IP pool as returned by the IP pools API.
+type ipPool struct {
+	PoolID      string   `json:"pool_id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IPs         []string `json:"ips"`
+	IsLinked    bool     `json:"is_linked"`
+}
+
+// createIPPool creates an IP pool and returns its ID.
+func createIPPool(ctx context.Context, client *mailgun.Client, name, description string, ips []string) (string, error) {
+	form := url.Values{
+		"name":        {name},
+		"description": {description},
+	}
+	for _, ip := range ips {
+		form.Add("ips", ip)
+	}
+
+	var resp struct {
+		PoolID string `json:"pool_id"`
+	}
+
+	if err := apiRequest(ctx, client, http.MethodPost, "/v1/ip_pools", form, &resp); err != nil {
+		return "", err
+	}
+
+	return resp.PoolID, nil
+}
+
+// getIPPool returns an IP pool.
+func getIPPool(ctx context.Context, client *mailgun.Client, poolID string) (*ipPool, error) {
+	var pool ipPool
+
+	if err := apiRequest(ctx, client, http.MethodGet, "/v1/ip_pools/"+url.PathEscape(poolID), nil, &pool); err != nil {
+		return nil, err
+	}
+
+	return &pool, nil
+}
+
+// updateIPPool changes the name, description and IPs of an IP pool. A nil
+// name or description is left unchanged.
+func updateIPPool(ctx context.Context, client *mailgun.Client, poolID string, name, description *string, addIPs, removeIPs []string) error {
+	form := url.Values{}
+
+	if name != nil {
+		form.Set("name", *name)
+	}
+	if description != nil {
+		form.Set("description", *description)
+	}
+	for _, ip := range addIPs {
+		form.Add("add_ip", ip)
+	}
+	for _, ip := range removeIPs {
+		form.Add("remove_ip", ip)
+	}
+
+	return apiRequest(ctx, client, http.MethodPatch, "/v1/ip_pools/"+url.PathEscape(poolID), form, nil)
+}
+
+// deleteIPPool deletes an IP pool. The domains linked to it are moved to
+// replacementPoolID, which Mailgun requires while the pool is in use.
+func deleteIPPool(ctx context.Context, client *mailgun.Client, poolID, replacementPoolID string) error {
+	path := "/v1/ip_pools/" + url.PathEscape(poolID)
+	if replacementPoolID != "" {
+		path += "?" + url.Values{"pool_id": {replacementPoolID}}.Encode()
+	}
+
+	return apiRequest(ctx, client, http.MethodDelete, path, nil, nil)
+}
+
+// ipWarmup is the warmup progress of a dedicated IP as returned by the IP
+// warmup API.
+type ipWarmup struct {
+	IP                   string      `json:"ip"`
+	SentWithinStage      json.Number `json:"sent_within_stage"`
+	Throttle             json.Number `json:"throttle"`
+	StageNumber          json.Number `json:"stage_number"`
+	StageStartVolume     json.Number `json:"stage_start_volume"`
+	StageStartTimestamp  string      `json:"stage_start_timestamp"`
+	StageVolumeLimit     json.Number `json:"stage_volume_limit"`
+	LastUpdatedTimestamp string      `json:"last_updated_timestamp"`
+}
+
+// startIPWarmup starts the warmup of a dedicated IP.
+func startIPWarmup(ctx context.Context, client *mailgun.Client, ip string) error {
+	return apiRequest(ctx, client, http.MethodPost, "/v3/ips/"+url.PathEscape(ip)+"/warmups", url.Values{}, nil)
+}
+
+// getIPWarmup returns the warmup progress of an IP. IPs that aren't warming
+// up are reported as not found.
+func getIPWarmup(ctx context.Context, client *mailgun.Client, ip string) (*ipWarmup, error) {
+	var resp struct {
+		Details ipWarmup `json:"details"`
+	}
+
+	if err := apiRequest(ctx, client, http.MethodGet, "/v3/ips/"+url.PathEscape(ip)+"/warmups", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Details, nil
+}
+
+// cancelIPWarmup cancels the warmup of an IP.
+func cancelIPWarmup(ctx context.Context, client *mailgun.Client, ip string) error {
+	return apiRequest(ctx, client, http.MethodDelete, "/v3/ips/"+url.PathEscape(ip)+"/warmups", nil, nil)
+}
+
+// templateVersion is mtypes.TemplateVersion with the headers the SDK
+// doesn't support.
+type templateVersion struct {
+	mtypes.TemplateVersion
+
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// template is a template with its active version.
+type template struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Version     templateVersion `json:"version"`
+}
+
+func templatePath(domain, name string) string {
+	return "/v3/" + url.PathEscape(domain) + "/templates/" + url.PathEscape(name)
+}
+
+// templateVersionForm encodes the content of a template version.
+func templateVersionForm(version *templateVersion) (url.Values, error) {
+	form := url.Values{"template": {version.Template}}
+
+	if version.Tag != "" {
+		form.Set("tag", version.Tag)
+	}
+	if version.Engine != "" {
+		form.Set("engine", string(version.Engine))
+	}
+	if version.Comment != "" {
+		form.Set("comment", version.Comment)
+	}
+	if len(version.Headers) > 0 {
+		headers, err := json.Marshal(version.Headers)
+		if err != nil {
+			return nil, err
+		}
+		form.Set("headers", string(headers))
+	}
+
+	return form, nil
+}
+
+// createTemplate creates a template with its first version.
+func createTemplate(ctx context.Context, client *mailgun.Client, domain, name, description string, version *templateVersion) error {
+	form, err := templateVersionForm(version)
+	if err != nil {
+		return err
+	}
+
+	form.Set("name", name)
+	if description != "" {
+		form.Set("description", description)
+	}
+
+	return apiRequest(ctx, client, http.MethodPost, "/v3/"+url.PathEscape(domain)+"/templates", form, nil)
+}
+
+// getTemplate returns a template with its active version.
+func getTemplate(ctx context.Context, client *mailgun.Client, domain, name string) (*template, error) {
+	var resp struct {
+		Template template `json:"template"`
+	}
+
+	if err := apiRequest(ctx, client, http.MethodGet, templatePath(domain, name)+"?active=yes", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Template, nil
+}
+
+// updateTemplateDescription changes the description of a template.
+func updateTemplateDescription(ctx context.Context, client *mailgun.Client, domain, name, description string) error {
+	form := url.Values{"description": {description}}
+
+	return apiRequest(ctx, client, http.MethodPut, templatePath(domain, name), form, nil)
+}
+
+// addTemplateVersion adds a version to a template and activates it.
+func addTemplateVersion(ctx context.Context, client *mailgun.Client, domain, name string, version *templateVersion) error {
+	form, err := templateVersionForm(version)
+	if err != nil {
+		return err
+	}
+
+	form.Set("active", "yes")
+
+	return apiRequest(ctx, client, http.MethodPost, templatePath(domain, name)+"/versions", form, nil)
+}
+
+// updateTemplateVersionComment changes the comment of a template version.
+func updateTemplateVersionComment(ctx context.Context, client *mailgun.Client, domain, name, tag, comment string) error {
+	form := url.Values{"comment": {comment}}
+
+	return apiRequest(ctx, client, http.MethodPut, templatePath(domain, name)+"/versions/"+url.PathEscape(tag), form, nil)
+}
@@ -0,0 +1,313 @@
+package mailgun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/mailgun/mailgun-go/v5"
+)
+
+func TestAPIRequest(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, key, _ := r.BasicAuth(); user != "api" || key != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v4/domains/example.com":
+			_ = r.ParseForm()
+			if r.Method != http.MethodPut || r.PostForm.Get("spam_action") != "tag" || r.PostForm.Get("wildcard") != "true" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"message": "Domain has been updated"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Domain not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := (&Config{APIKey: "key", APIBaseURL: server.URL}).GetClient("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var resp struct {
+		Message string `json:"message"`
+	}
+
+	form := url.Values{"spam_action": {"tag"}, "wildcard": {"true"}}
+	if err := apiRequest(context.Background(), client, http.MethodPut, "/v4/domains/example.com", form, &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Message != "Domain has been updated" {
+		t.Fatalf("unexpected response %q", resp.Message)
+	}
+
+	err = apiRequest(context.Background(), client, http.MethodGet, "/v4/domains/missing.com", nil, nil)
+	if mailgun.GetStatusFromErr(err) != http.StatusNotFound || !isNotFound(err) {
+		t.Fatalf("expected a 404 error, got %v", err)
+	}
+}
+
+func TestDKIMKeys(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/dkim/keys":
+			_ = r.ParseForm()
+			if r.PostForm.Get("signing_domain") != "example.com" || r.PostForm.Get("selector") != "s2" || r.PostForm.Get("bits") != "2048" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"signing_domain": "example.com", "selector": "s2", "dns_record": {"name": "s2._domainkey.example.com", "record_type": "TXT", "valid": "unknown", "value": "k=rsa; p=abc"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/domains/example.com/keys":
+			_, _ = w.Write([]byte(`{"items": [
+				{"signing_domain": "example.com", "selector": "s1", "dns_record": {"is_active": true, "valid": "valid"}},
+				{"signing_domain": "example.com", "selector": "s2", "dns_record": {"is_active": false, "valid": "unknown"}}
+			]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/dkim/keys":
+			if r.URL.Query().Get("selector") != "s2" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"message": "success"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	client, err := (&Config{APIKey: "key", APIBaseURL: server.URL}).GetClient("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	key, err := createDKIMKey(ctx, client, "example.com", "s2", 2048, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if key.DNSRecord.Name != "s2._domainkey.example.com" || key.DNSRecord.Value != "k=rsa; p=abc" {
+		t.Fatalf("unexpected key %+v", key)
+	}
+
+	key, err = getDomainKey(ctx, client, "example.com", "s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !key.DNSRecord.Active || key.DNSRecord.Valid != dkimRecordValid {
+		t.Fatalf("unexpected key %+v", key)
+	}
+
+	if _, err := getDomainKey(ctx, client, "example.com", "s3"); !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	if err := deleteDKIMKey(ctx, client, "example.com", "s2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDomainIPPool(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/domains/example.com":
+			_, _ = w.Write([]byte(`{"domain": {"name": "example.com", "pool_id": "pool-1"}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/domains/example.com/ips":
+			_ = r.ParseForm()
+			if r.PostForm.Get("pool_id") != "pool-1" || r.PostForm.Get("ip") != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"message": "success"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v3/domains/example.com/ips/ip_pool":
+			if r.URL.Query().Get("pool_id") != "pool-2" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"message": "success"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	client, err := (&Config{APIKey: "key", APIBaseURL: server.URL}).GetClient("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	domain, poolID, err := getDomain(ctx, client, "example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if domain.Domain.Name != "example.com" || poolID != "pool-1" {
+		t.Fatalf("unexpected domain %+v with pool %q", domain.Domain, poolID)
+	}
+
+	if err := linkDomainIPPool(ctx, client, "example.com", "pool-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := unlinkDomainIPPool(ctx, client, "example.com", "pool-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestIPPools(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/ip_pools":
+			_ = r.ParseForm()
+			if r.PostForm.Get("name") != "pool" || len(r.PostForm["ips"]) != 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"message": "success", "pool_id": "pool-1"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/ip_pools/pool-1":
+			_, _ = w.Write([]byte(`{"pool_id": "pool-1", "name": "pool", "description": "", "ips": ["10.0.0.1", "10.0.0.2"], "is_linked": true}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/v1/ip_pools/pool-1":
+			_ = r.ParseForm()
+			if _, ok := r.PostForm["name"]; ok || r.PostForm.Get("description") != "" || r.PostForm.Get("add_ip") != "10.0.0.3" || r.PostForm.Get("remove_ip") != "10.0.0.1" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"message": "success"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/ip_pools/pool-1":
+			if r.URL.Query().Get("pool_id") != "pool-2" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"message": "started"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	client, err := (&Config{APIKey: "key", APIBaseURL: server.URL}).GetClient("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	poolID, err := createIPPool(ctx, client, "pool", "", []string{"10.0.0.1", "10.0.0.2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if poolID != "pool-1" {
+		t.Fatalf("expected pool-1, got %q", poolID)
+	}
+
+	pool, err := getIPPool(ctx, client, poolID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pool.Name != "pool" || len(pool.IPs) != 2 || !pool.IsLinked {
+		t.Fatalf("unexpected pool %+v", pool)
+	}
+
+	description := ""
+	if err := updateIPPool(ctx, client, poolID, nil, &description, []string{"10.0.0.3"}, []string{"10.0.0.1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := deleteIPPool(ctx, client, poolID, "pool-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := getIPPool(ctx, client, "missing"); !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/example.com/templates":
+			_ = r.ParseForm()
+			if r.PostForm.Get("name") != "welcome" || r.PostForm.Get("template") != "<p>Hi</p>" || r.PostForm.Get("headers") != `{"Subject":"Welcome"}` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"message": "template has been stored"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v3/example.com/templates/welcome":
+			if r.URL.Query().Get("active") != "yes" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"template": {"name": "welcome", "description": "", "version": {"tag": "v2", "template": "<p>Hello</p>", "engine": "handlebars", "active": true, "headers": {"Subject": "Welcome"}}}}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v3/example.com/templates/welcome/versions":
+			_ = r.ParseForm()
+			if r.PostForm.Get("tag") != "v2" || r.PostForm.Get("active") != "yes" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"message": "new version of the template has been stored"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	client, err := (&Config{APIKey: "key", APIBaseURL: server.URL}).GetClient("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	version := &templateVersion{Headers: map[string]string{"Subject": "Welcome"}}
+	version.Template = "<p>Hi</p>"
+
+	if err := createTemplate(ctx, client, "example.com", "welcome", "", version); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	version.Tag = "v2"
+	version.Template = "<p>Hello</p>"
+
+	if err := addTemplateVersion(ctx, client, "example.com", "welcome", version); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tmpl, err := getTemplate(ctx, client, "example.com", "welcome")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.Version.Tag != "v2" || tmpl.Version.Template != "<p>Hello</p>" || tmpl.Version.Headers["Subject"] != "Welcome" {
+		t.Fatalf("unexpected template %+v", tmpl)
+	}
+
+	if _, err := getTemplate(ctx, client, "example.com", "missing"); !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
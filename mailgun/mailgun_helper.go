@@ -1,22 +1,194 @@
 package mailgun
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"errors"
+	"fmt"
 	"hash/crc32"
+	"net/http"
 	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mailgun/mailgun-go/v5"
 )
 
-func setDefaultRegionForImport(d *schema.ResourceData) {
+// errNotFound is returned when a resource looked up in a list returned by
+// Mailgun isn't part of it anymore.
+var errNotFound = errors.New("not found")
+
+// isNotFound reports whether err means that the resource doesn't exist in
+// Mailgun anymore, either because the API answered 404 or because it is
+// missing from a list. Wrapped errors are unwrapped.
+func isNotFound(err error) bool {
+	if errors.Is(err, errNotFound) {
+		return true
+	}
+
+	var respErr *mailgun.UnexpectedResponseError
+	return errors.As(err, &respErr) && respErr.Actual == http.StatusNotFound
+}
+
+func setDefaultRegionForImport(d *schema.ResourceData, meta interface{}) {
+	setAccountForImport(d, meta)
+
 	parts := strings.SplitN(d.Id(), ":", 2)
 
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		_ = d.Set("region", "us")
+		_ = d.Set("region", resourceConfig(d, meta).Region)
 	} else {
 		_ = d.Set("region", parts[0])
 		d.SetId(parts[1])
 	}
 }
 
+// setAccountForImport strips an "account/" prefix naming one of the provider
+// accounts from the import ID and stores it in the account attribute.
+func setAccountForImport(d *schema.ResourceData, meta interface{}) {
+	if _, ok := d.GetOk("account"); ok {
+		return
+	}
+
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) != 2 {
+		return
+	}
+
+	if _, ok := meta.(*Config).Accounts[parts[0]]; ok {
+		_ = d.Set("account", parts[0])
+		d.SetId(parts[1])
+	}
+}
+
+// accountConfig returns the configuration of the provider account selected by
+// the account attribute, or the provider configuration if none is set.
+func accountConfig(d *schema.ResourceData, meta interface{}) (*Config, error) {
+	account, _ := d.Get("account").(string)
+
+	return meta.(*Config).Account(account)
+}
+
+// resourceClient returns the client for the account, region and subaccount
+// stored on d. Resources without a region attribute use the account region.
+func resourceClient(d *schema.ResourceData, meta interface{}) (*mailgun.Client, error) {
+	config, err := accountConfig(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	region, _ := d.Get("region").(string)
+	subaccountID, _ := d.Get("subaccount_id").(string)
+
+	return config.GetSubaccountClient(region, subaccountID)
+}
+
+// accountClient returns the client of the primary account selected by the
+// account attribute of d, for managing subaccounts.
+func accountClient(d *schema.ResourceData, meta interface{}) (*mailgun.Client, error) {
+	config, err := accountConfig(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return config.GetAccountClient(d.Get("region").(string))
+}
+
+// resourceConfig is like accountConfig but falls back to the provider
+// configuration for an unknown account, which is then reported when the
+// client is requested.
+func resourceConfig(d *schema.ResourceData, meta interface{}) *Config {
+	config, err := accountConfig(d, meta)
+	if err != nil {
+		return meta.(*Config)
+	}
+
+	return config
+}
+
+// setDefaultRegion stores the account region on resources that don't
+// configure their own, so the region used at create time ends up in state.
+func setDefaultRegion(d *schema.ResourceData, meta interface{}) {
+	if d.Get("region").(string) == "" {
+		_ = d.Set("region", resourceConfig(d, meta).Region)
+	}
+}
+
+// setDefaultSubaccount stores the account subaccount on resources that
+// don't configure their own, so later calls act on the same subaccount. It is
+// only called on create and import: an empty subaccount_id in state means
+// the primary account.
+func setDefaultSubaccount(d *schema.ResourceData, meta interface{}) {
+	if d.Get("subaccount_id").(string) == "" {
+		_ = d.Set("subaccount_id", resourceConfig(d, meta).SubaccountID)
+	}
+}
+
+// accountSchema returns the account attribute of a resource managing what,
+// for example "the domain".
+func accountSchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: fmt.Sprintf("Name of the provider `accounts` entry to manage %s with. Defaults to the provider credentials.", what),
+	}
+}
+
+// dataSourceAccountSchema returns the account attribute of a data source
+// reading what.
+func dataSourceAccountSchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: fmt.Sprintf("Name of the provider `accounts` entry to read %s with. Defaults to the provider credentials.", what),
+	}
+}
+
+// dataSourceSubaccountSchema returns the subaccount_id attribute of a data
+// source reading what.
+func dataSourceSubaccountSchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: fmt.Sprintf("Read %s on behalf of this subaccount instead of the provider `subaccount_id`.", what),
+	}
+}
+
+// subaccountSchema returns the subaccount_id attribute of a resource
+// managing what. It is filled in by setDefaultSubaccount on create and
+// import.
+func subaccountSchema(what string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: fmt.Sprintf("Manage %s on behalf of this subaccount instead of the provider `subaccount_id`.", what),
+	}
+}
+
+// isConfigured reports whether key is set in the configuration, as opposed to
+// only being present in the state.
+func isConfigured(d *schema.ResourceData, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+
+	v := config.GetAttr(key)
+
+	return !v.IsNull()
+}
+
+// expandStringSet converts a set of strings to a slice.
+func expandStringSet(set *schema.Set) []string {
+	result := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		result = append(result, v.(string))
+	}
+
+	return result
+}
+
 // stringHashcode hashes a string to a unique hashcode.
 //
 // crc32 returns an uint32, but for our use we need
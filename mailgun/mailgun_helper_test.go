@@ -0,0 +1,105 @@
+package mailgun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mailgun/mailgun-go/v5"
+)
+
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/routes/missing":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		_, _ = w.Write([]byte(`{"message": "error"}`))
+	}))
+	defer server.Close()
+
+	client, err := (&Config{APIKey: "key", APIBaseURL: server.URL}).GetClient("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, notFound := client.GetRoute(context.Background(), "missing")
+	_, badRequest := client.GetRoute(context.Background(), "invalid")
+
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"404", notFound, true},
+		{"wrapped 404", fmt.Errorf("Error retrieving route: %w", notFound), true},
+		{"missing from list", fmt.Errorf("API key abc %w", errNotFound), true},
+		{"400", badRequest, false},
+		{"other error", errors.New("connection refused"), false},
+	}
+
+	for _, tc := range cases {
+		if isNotFound(tc.err) != tc.expected {
+			t.Fatalf("%s: expected isNotFound to be %t for %v", tc.name, tc.expected, tc.err)
+		}
+	}
+}
+
+func TestResourceClient(t *testing.T) {
+	t.Parallel()
+
+	type request struct{ apiKey, subaccountID string }
+
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, apiKey, _ := r.BasicAuth()
+		requests <- request{apiKey, r.Header.Get(mailgun.OnBehalfOfHeader)}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"route": {"id": "route-id"}}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		APIKey:       "provider-key",
+		Region:       "us",
+		APIBaseURL:   server.URL,
+		SubaccountID: "provider-sub",
+		Accounts: map[string]Account{
+			"prod": {APIKey: "prod-key"},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceMailgunRoute().Schema, map[string]interface{}{
+		"account":       "prod",
+		"subaccount_id": "route-sub",
+	})
+
+	client, err := resourceClient(d, config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := client.GetRoute(context.Background(), "route-id"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r := <-requests; r != (request{"prod-key", "route-sub"}) {
+		t.Fatalf("expected the account key and stored subaccount, got %+v", r)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceMailgunRoute().Schema, map[string]interface{}{
+		"account": "staging",
+	})
+
+	if _, err := resourceClient(d, config); err == nil {
+		t.Fatal("expected an error for an unknown account")
+	}
+}
@@ -1,11 +1,16 @@
 package mailgun
 
 import (
+	"bytes"
 	"context"
 	"log"
+	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -14,37 +19,346 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("MAILGUN_API_KEY", nil),
 			},
+
+			"api_key_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"api_key_command"},
+				Description:   "Path to a file containing the API key, for example a mounted secret.",
+			},
+
+			"api_key_command": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MinItems:      1,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"api_key_file"},
+				Description:   "Command and arguments whose standard output is the API key. The command is run without a shell.",
+			},
+
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MAILGUN_REGION", "us"),
+				ValidateFunc: validation.StringInSlice([]string{"us", "eu"}, true),
+				Description:  "Default region for resources that don't set their own `region`.",
+			},
+
+			"api_base_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("MAILGUN_API_BASE_URL", nil),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "Override the Mailgun API base URL for every region, e.g. to use a proxy or a local fake API.",
+			},
+
+			"subaccount_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("MAILGUN_SUBACCOUNT_ID", nil),
+				Description: "Manage resources on behalf of this subaccount by sending the `X-Mailgun-On-Behalf-Of` header.",
+			},
+
+			"http_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description:  "Proxy used for every request to Mailgun, instead of the HTTP(S)_PROXY environment variables.",
+			},
+
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM encoded CA certificates trusted in addition to the system pool.",
+			},
+
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path to a PEM file with CA certificates trusted in addition to the system pool.",
+			},
+
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip TLS certificate verification. Only meant for testing.",
+			},
+
+			"request_timeout": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Maximum time for a single request, including reading the response (for example, \"30s\"). Unset means no timeout.",
+			},
+
+			"validate_credentials": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Check the API key with a cheap authenticated call while configuring the provider.",
+			},
+
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of retries for requests rejected with 429, or with a 5xx status for idempotent methods.",
+			},
+
+			"retry_min_wait": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultRetryMinWait.String(),
+				Description: "Initial wait between retries (for example, \"1s\"). Doubles on every attempt.",
+			},
+
+			"retry_max_wait": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     defaultRetryMaxWait.String(),
+				Description: "Maximum wait between retries (for example, \"30s\"), also used to cap `Retry-After`.",
+			},
+
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of API requests per second across all resources. `0` disables the limit.",
+			},
+
+			"burst": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultBurst,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of requests that may be sent at once before `requests_per_second` applies.",
+			},
+
+			"accounts": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Additional named Mailgun accounts that resources can select with their `account` attribute.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotWhiteSpace,
+						},
+
+						"api_key": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+
+						"region": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"us", "eu"}, true),
+							Description:  "Default region of the account. Defaults to the provider `region`.",
+						},
+
+						"subaccount": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Manage resources of this account on behalf of the given subaccount ID.",
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"mailgun_domain": dataSourceMailgunDomain(),
+			"mailgun_domain":      dataSourceMailgunDomain(),
+			"mailgun_subaccounts": dataSourceMailgunSubaccounts(),
+			"mailgun_ips":         dataSourceMailgunIPs(),
+			"mailgun_ip":          dataSourceMailgunIP(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
 			"mailgun_api_key":             resourceMailgunApiKey(),
 			"mailgun_domain":              resourceMailgunDomain(),
 			"mailgun_domain_verification": resourceMailgunDomainVerification(),
+			"mailgun_domain_dkim_key":     resourceMailgunDomainDkimKey(),
 			"mailgun_route":               resourceMailgunRoute(),
 			"mailgun_domain_credential":   resourceMailgunCredential(),
 			"mailgun_webhook":             resourceMailgunWebhook(),
+			"mailgun_subaccount":          resourceMailgunSubaccount(),
+			"mailgun_ip_pool":             resourceMailgunIPPool(),
+			"mailgun_ip_warmup":           resourceMailgunIPWarmup(),
+			"mailgun_template":            resourceMailgunTemplate(),
 		},
 	}
 
 	p.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		return providerConfigure(d)
+		return providerConfigure(ctx, d)
 	}
 
 	return p
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	retryMinWait, err := parseDurationWithDefault(d.Get("retry_min_wait").(string), defaultRetryMinWait)
+	if err != nil {
+		return nil, diag.Errorf("retry_min_wait: %s", err)
+	}
+
+	retryMaxWait, err := parseDurationWithDefault(d.Get("retry_max_wait").(string), defaultRetryMaxWait)
+	if err != nil {
+		return nil, diag.Errorf("retry_max_wait: %s", err)
+	}
+
+	if retryMaxWait < retryMinWait {
+		return nil, diag.Errorf("retry_max_wait (%s) must not be shorter than retry_min_wait (%s)", retryMaxWait, retryMinWait)
+	}
+
+	requestTimeout, err := parseDurationWithDefault(d.Get("request_timeout").(string), 0)
+	if err != nil {
+		return nil, diag.Errorf("request_timeout: %s", err)
+	}
+
+	caCertPEM := d.Get("ca_cert_pem").(string)
+	if path := d.Get("ca_cert_file").(string); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, diag.Errorf("Error reading ca_cert_file: %s", err)
+		}
+		caCertPEM = string(data)
+	}
+
+	accounts, diags := expandProviderAccounts(d.Get("accounts").([]interface{}))
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	apiKey, diags := resolveAPIKey(ctx, d)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if apiKey == "" && len(accounts) == 0 {
+		return nil, diag.Errorf("One of api_key, api_key_file or api_key_command must be configured, or MAILGUN_API_KEY must be set")
+	}
+
 	config := Config{
-		APIKey: d.Get("api_key").(string),
+		APIKey:             apiKey,
+		Region:             strings.ToLower(d.Get("region").(string)),
+		APIBaseURL:         d.Get("api_base_url").(string),
+		SubaccountID:       d.Get("subaccount_id").(string),
+		HTTPProxy:          d.Get("http_proxy").(string),
+		CACertPEM:          caCertPEM,
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+		RequestTimeout:     requestTimeout,
+		MaxRetries:         d.Get("max_retries").(int),
+		RetryMinWait:       retryMinWait,
+		RetryMaxWait:       retryMaxWait,
+		RequestsPerSecond:  d.Get("requests_per_second").(float64),
+		Burst:              d.Get("burst").(int),
+		Accounts:           accounts,
 	}
 
 	log.Println("[INFO] Initializing Mailgun client")
+
+	if d.Get("validate_credentials").(bool) {
+		if config.APIKey != "" {
+			if diags := config.ValidateCredentials(ctx); diags.HasError() {
+				return nil, diags
+			}
+		}
+
+		for _, raw := range d.Get("accounts").([]interface{}) {
+			account, err := config.Account(raw.(map[string]interface{})["name"].(string))
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+
+			if diags := account.ValidateCredentials(ctx); diags.HasError() {
+				return nil, diags
+			}
+		}
+	}
+
 	return config.Client()
 }
+
+// resolveAPIKey returns the API key from api_key_file, the output of
+// api_key_command or api_key. api_key can't use ConflictsWith because it is
+// also filled in from MAILGUN_API_KEY, which may stay in the environment of
+// configurations that use a file or a command, so the conflict is only
+// checked against the configuration itself.
+func resolveAPIKey(ctx context.Context, d *schema.ResourceData) (string, diag.Diagnostics) {
+	if isConfigured(d, "api_key") && (isConfigured(d, "api_key_file") || isConfigured(d, "api_key_command")) {
+		return "", diag.Errorf("api_key conflicts with api_key_file and api_key_command, only one of them can be set")
+	}
+
+	if path := d.Get("api_key_file").(string); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", diag.Errorf("Error reading api_key_file: %s", err)
+		}
+
+		apiKey := strings.TrimSpace(string(data))
+		if apiKey == "" {
+			return "", diag.Errorf("api_key_file %s is empty", path)
+		}
+
+		return apiKey, nil
+	}
+
+	if raw := d.Get("api_key_command").([]interface{}); len(raw) > 0 {
+		args := make([]string, len(raw))
+		for i, arg := range raw {
+			args[i], _ = arg.(string)
+		}
+
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Stderr = &stderr
+
+		log.Printf("[DEBUG] Reading Mailgun API key from command %q", args[0])
+
+		out, err := cmd.Output()
+		if err != nil {
+			return "", diag.Errorf("Error running api_key_command %q: %s: %s", args[0], err, strings.TrimSpace(stderr.String()))
+		}
+
+		apiKey := strings.TrimSpace(string(out))
+		if apiKey == "" {
+			return "", diag.Errorf("api_key_command %q printed no API key", args[0])
+		}
+
+		return apiKey, nil
+	}
+
+	return d.Get("api_key").(string), nil
+}
+
+// expandProviderAccounts turns the accounts block into Config.Accounts.
+func expandProviderAccounts(raw []interface{}) (map[string]Account, diag.Diagnostics) {
+	accounts := make(map[string]Account, len(raw))
+
+	for _, v := range raw {
+		m := v.(map[string]interface{})
+		name := m["name"].(string)
+
+		if _, ok := accounts[name]; ok {
+			return nil, diag.Errorf("accounts: duplicate account name %q", name)
+		}
+
+		accounts[name] = Account{
+			APIKey:       m["api_key"].(string),
+			Region:       strings.ToLower(m["region"].(string)),
+			SubaccountID: m["subaccount"].(string),
+		}
+	}
+
+	return accounts, nil
+}
@@ -1,10 +1,17 @@
 package mailgun
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 var testAccProvider *schema.Provider
@@ -28,6 +35,150 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+func TestProviderConfigure(t *testing.T) {
+	raw := map[string]interface{}{
+		"api_key":              "key",
+		"region":               "EU",
+		"subaccount_id":        "sub",
+		"http_proxy":           "http://proxy.example.com:3128",
+		"request_timeout":      "20s",
+		"retry_max_wait":       "10s",
+		"validate_credentials": false,
+	}
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+
+	meta, diags := providerConfigure(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %#v", diags)
+	}
+
+	config := meta.(*Config)
+
+	if config.Region != "eu" {
+		t.Fatalf("expected region eu, got %q", config.Region)
+	}
+	if config.SubaccountID != "sub" {
+		t.Fatalf("expected subaccount sub, got %q", config.SubaccountID)
+	}
+	if config.HTTPProxy != "http://proxy.example.com:3128" {
+		t.Fatalf("unexpected http_proxy %q", config.HTTPProxy)
+	}
+	if config.RequestTimeout != 20*time.Second {
+		t.Fatalf("expected request timeout 20s, got %s", config.RequestTimeout)
+	}
+	if config.MaxRetries != defaultMaxRetries || config.RetryMinWait != defaultRetryMinWait || config.RetryMaxWait != 10*time.Second {
+		t.Fatalf("unexpected retry settings: %d, %s, %s", config.MaxRetries, config.RetryMinWait, config.RetryMaxWait)
+	}
+}
+
+func TestResolveAPIKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_key")
+	if err := os.WriteFile(path, []byte("file-key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		raw      map[string]interface{}
+		expected string
+	}{
+		"api_key":         {map[string]interface{}{"api_key": "inline-key"}, "inline-key"},
+		"api_key_file":    {map[string]interface{}{"api_key_file": path}, "file-key"},
+		"api_key_command": {map[string]interface{}{"api_key_command": []interface{}{"echo", " command-key "}}, "command-key"},
+	}
+
+	for name, tc := range cases {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, tc.raw)
+
+		apiKey, diags := resolveAPIKey(context.Background(), d)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %#v", name, diags)
+		}
+		if apiKey != tc.expected {
+			t.Fatalf("%s: expected %q, got %q", name, tc.expected, apiKey)
+		}
+	}
+
+	for name, raw := range map[string]map[string]interface{}{
+		"missing file":    {"api_key_file": filepath.Join(t.TempDir(), "missing")},
+		"failing command": {"api_key_command": []interface{}{"false"}},
+		"empty output":    {"api_key_command": []interface{}{"true"}},
+	} {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+
+		if _, diags := resolveAPIKey(context.Background(), d); !diags.HasError() {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestProviderConfigure_APIKeyConflicts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "api_key")
+	if err := os.WriteFile(path, []byte("file-key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// MAILGUN_API_KEY fills in api_key, but doesn't conflict with the file.
+	t.Setenv("MAILGUN_API_KEY", "env-key")
+
+	configure := func(raw string) (*schema.Provider, diag.Diagnostics) {
+		p := Provider()
+		block := schema.InternalMap(p.Schema).CoreConfigSchema()
+
+		val, err := ctyjson.Unmarshal([]byte(raw), block.ImpliedType())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Terraform passes the raw configuration along with the shimmed one,
+		// which is what GetRawConfig reads.
+		config := terraform.NewResourceConfigShimmed(val, block)
+		config.CtyValue = val
+
+		return p, p.Configure(context.Background(), config)
+	}
+
+	p, diags := configure(fmt.Sprintf(`{"api_key_file": %q, "validate_credentials": false}`, path))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %#v", diags)
+	}
+	if apiKey := p.Meta().(*Config).APIKey; apiKey != "file-key" {
+		t.Fatalf("expected the API key from the file, got %q", apiKey)
+	}
+
+	for name, raw := range map[string]string{
+		"api_key and api_key_file":    fmt.Sprintf(`{"api_key": "inline-key", "api_key_file": %q, "validate_credentials": false}`, path),
+		"api_key and api_key_command": `{"api_key": "inline-key", "api_key_command": ["echo", "command-key"], "validate_credentials": false}`,
+	} {
+		if _, diags := configure(raw); !diags.HasError() {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestExpandProviderAccounts(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{"name": "prod", "api_key": "prod-key", "region": "EU", "subaccount": ""},
+		map[string]interface{}{"name": "marketing", "api_key": "marketing-key", "region": "", "subaccount": "sub"},
+	}
+
+	accounts, diags := expandProviderAccounts(raw)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %#v", diags)
+	}
+
+	if accounts["prod"] != (Account{APIKey: "prod-key", Region: "eu"}) {
+		t.Fatalf("unexpected prod account %+v", accounts["prod"])
+	}
+	if accounts["marketing"] != (Account{APIKey: "marketing-key", SubaccountID: "sub"}) {
+		t.Fatalf("unexpected marketing account %+v", accounts["marketing"])
+	}
+
+	if _, diags := expandProviderAccounts(append(raw, raw[0])); !diags.HasError() {
+		t.Fatal("expected an error for duplicate account names")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("MAILGUN_API_KEY"); v == "" {
 		t.Fatal("MAILGUN_API_KEY must be set for acceptance tests")
@@ -79,12 +79,17 @@ func resourceMailgunApiKey() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"account": accountSchema("the API key"),
+
+			"subaccount_id": subaccountSchema("the API key"),
 		},
 	}
 }
 
 func resourceMailgunApiKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient("us")
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -121,7 +126,7 @@ func resourceMailgunApiKeyCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceMailgunApiKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient("us")
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -129,7 +134,7 @@ func resourceMailgunApiKeyDelete(ctx context.Context, d *schema.ResourceData, me
 	log.Printf("[INFO] Deleting API key: %s", d.Id())
 
 	// Destroy the API key
-	err := client.DeleteAPIKey(context.Background(), d.Id())
+	err := client.DeleteAPIKey(ctx, d.Id())
 	if err != nil {
 		return diag.Errorf("Error deleting API key: %s", err)
 	}
@@ -139,25 +144,31 @@ func resourceMailgunApiKeyDelete(ctx context.Context, d *schema.ResourceData, me
 
 func resourceMailgunApiKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client, errc := meta.(*Config).GetClient("us")
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
 
-	err := resourceMailgunApiKeyRetrieve(d.Id(), client, d)
+	err := resourceMailgunApiKeyRetrieve(ctx, d.Id(), client, d)
 
 	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] API key %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceMailgunApiKeyRetrieve(id string, client *mailgun.Client, d *schema.ResourceData) error {
-	resp, err := client.ListAPIKeys(context.Background(), nil)
+func resourceMailgunApiKeyRetrieve(ctx context.Context, id string, client *mailgun.Client, d *schema.ResourceData) error {
+	resp, err := client.ListAPIKeys(ctx, nil)
 
 	if err != nil {
-		return fmt.Errorf("Error retrieving API key list: %s", err)
+		return fmt.Errorf("Error retrieving API key list: %w", err)
 	}
 
 	var apiKey mtypes.APIKey
@@ -170,7 +181,7 @@ func resourceMailgunApiKeyRetrieve(id string, client *mailgun.Client, d *schema.
 
 	if apiKey.ID == "" {
 		log.Printf("[DEBUG] API key not found with ID: %s", d.Id())
-		return fmt.Errorf("API key not found: %s", id)
+		return fmt.Errorf("API key %s %w", id, errNotFound)
 	}
 
 	_ = d.Set("requestor", apiKey.Requestor)
@@ -42,7 +42,7 @@ func testAccCheckMailgunApiKeyDestroy(s *terraform.State) error {
 			continue
 		}
 
-		client, errc := testAccProvider.Meta().(*Config).GetClient(rs.Primary.Attributes["region"])
+		client, errc := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
 		if errc != nil {
 			return errc
 		}
@@ -81,7 +81,7 @@ func testAccCheckMailgunApiKeyExists(n string, APIKey *mtypes.APIKey) resource.T
 			return fmt.Errorf("No API key ID is set")
 		}
 
-		client, errc := testAccProvider.Meta().(*Config).GetClient(rs.Primary.Attributes["region"])
+		client, errc := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
 		if errc != nil {
 			return errc
 		}
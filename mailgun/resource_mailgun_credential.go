@@ -17,9 +17,9 @@ func resourceMailgunCredential() *schema.Resource {
 
 	return &schema.Resource{
 		CreateContext: resourceMailgunCredentialCreate,
-		Read:          resourceMailgunCredentialRead,
-		Update:        resourceMailgunCredentialUpdate,
-		Delete:        resourceMailgunCredentialDelete,
+		ReadContext:   resourceMailgunCredentialRead,
+		UpdateContext: resourceMailgunCredentialUpdate,
+		DeleteContext: resourceMailgunCredentialDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceMailgunCredentialImport,
 		},
@@ -44,19 +44,24 @@ func resourceMailgunCredential() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"account": accountSchema("the credential"),
+
 			"region": {
 				Type:     schema.TypeString,
 				ForceNew: true,
 				Optional: true,
-				Default:  "us",
+				Computed: true,
 			},
+
+			"subaccount_id": subaccountSchema("the credential"),
 		},
 	}
 }
 
 func resourceMailgunCredentialImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
-	setDefaultRegionForImport(d)
+	setDefaultRegionForImport(d, meta)
+	setDefaultSubaccount(d, meta)
 
 	log.Printf("[DEBUG] Import credential for region '%s' and email '%s'", d.Get("region"), d.Id())
 
@@ -64,7 +69,10 @@ func resourceMailgunCredentialImport(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceMailgunCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -74,7 +82,7 @@ func resourceMailgunCredentialCreate(ctx context.Context, d *schema.ResourceData
 
 	log.Printf("[DEBUG] Credential create configuration: email: %s", email)
 
-	err := client.CreateCredential(context.Background(), d.Get("domain").(string), email, password)
+	err := client.CreateCredential(ctx, d.Get("domain").(string), email, password)
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -87,10 +95,10 @@ func resourceMailgunCredentialCreate(ctx context.Context, d *schema.ResourceData
 	return nil
 }
 
-func resourceMailgunCredentialUpdate(d *schema.ResourceData, meta interface{}) error {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+func resourceMailgunCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
-		return errc
+		return diag.FromErr(errc)
 	}
 
 	email := fmt.Sprintf("%s@%s", d.Get("login").(string), d.Get("domain").(string))
@@ -98,10 +106,10 @@ func resourceMailgunCredentialUpdate(d *schema.ResourceData, meta interface{}) e
 
 	log.Printf("[DEBUG] Credential create configuration: email: %s", email)
 
-	err := client.ChangeCredentialPassword(context.Background(), d.Get("domain").(string), email, password)
+	err := client.ChangeCredentialPassword(ctx, d.Get("domain").(string), email, password)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(email)
@@ -111,42 +119,42 @@ func resourceMailgunCredentialUpdate(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceMailgunCredentialDelete(d *schema.ResourceData, meta interface{}) error {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+func resourceMailgunCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
-		return errc
+		return diag.FromErr(errc)
 	}
 
 	email := fmt.Sprintf("%s@%s", d.Get("login").(string), d.Get("domain").(string))
-	err := client.DeleteCredential(context.Background(), d.Get("domain").(string), email)
+	err := client.DeleteCredential(ctx, d.Get("domain").(string), email)
 
 	if err != nil {
-		return fmt.Errorf("Error deleting credential: %s", err)
+		return diag.Errorf("Error deleting credential: %s", err)
 	}
 
 	return nil
 }
 
-func resourceMailgunCredentialRead(d *schema.ResourceData, meta interface{}) error {
+func resourceMailgunCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	parts := strings.SplitN(d.Id(), "@", 2)
 
 	if len(parts) != 2 {
-		return fmt.Errorf("The ID of credential '%s' don't contains domain!", d.Id())
+		return diag.Errorf("The ID of credential '%s' don't contains domain!", d.Id())
 	}
 
 	login := parts[0]
 	domain := parts[1]
 
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
-		return errc
+		return diag.FromErr(errc)
 	}
 
 	log.Printf("[DEBUG] Read credential for region '%s' and email '%s'", d.Get("region"), d.Id())
 
 	itCredentials := client.ListCredentials(domain, nil)
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
 
 	var page []mtypes.Credential
@@ -163,9 +171,13 @@ func resourceMailgunCredentialRead(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	if err := itCredentials.Err(); err != nil {
-		return err
+	if err := itCredentials.Err(); err != nil && !isNotFound(err) {
+		return diag.FromErr(err)
 	}
 
-	return fmt.Errorf("The credential '%s' not found!", d.Id())
+	// Either the credential or its whole domain is gone.
+	log.Printf("[WARN] Credential %s not found, removing from state", d.Id())
+	d.SetId("")
+
+	return nil
 }
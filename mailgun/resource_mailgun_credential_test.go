@@ -89,7 +89,7 @@ func testAccCheckMailgunCrendentialDestroy(s *terraform.State) error {
 			continue
 		}
 
-		client, err := testAccProvider.Meta().(*Config).GetClient(rs.Primary.Attributes["region"])
+		client, err := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
 
 		resp, err := client.GetDomain(context.Background(), rs.Primary.Attributes["domain"], nil)
 		if err == nil {
@@ -133,7 +133,7 @@ func testAccCheckMailgunCredentialExists(n string) resource.TestCheckFunc {
 			return fmt.Errorf("No domain credential ID is set")
 		}
 
-		client, _ := testAccProvider.Meta().(*Config).GetClient(rs.Primary.Attributes["region"])
+		client, _ := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
 
 		itCredentials := client.ListCredentials(rs.Primary.Attributes["domain"], nil)
 
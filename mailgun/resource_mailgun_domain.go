@@ -2,16 +2,23 @@ package mailgun
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/mailgun/mailgun-go/v5/mtypes"
 	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mailgun/mailgun-go/v5"
 )
 
@@ -31,16 +38,19 @@ func resourceMailgunDomain() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"account": accountSchema("the domain"),
+
 			"region": {
 				Type:     schema.TypeString,
 				ForceNew: true,
 				Optional: true,
-				Default:  "us",
+				Computed: true,
 			},
 
+			"subaccount_id": subaccountSchema("the domain"),
+
 			"spam_action": {
 				Type:     schema.TypeString,
-				ForceNew: true,
 				Optional: true,
 				Default:  "disabled",
 			},
@@ -51,28 +61,41 @@ func resourceMailgunDomain() *schema.Resource {
 			},
 
 			"smtp_password": {
-				Type:      schema.TypeString,
-				Optional:  true,
-				ForceNew:  false,
-				Sensitive: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      false,
+				Sensitive:     true,
+				ConflictsWith: []string{"smtp_password_wo"},
+			},
+
+			"smtp_password_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				WriteOnly:     true,
+				Sensitive:     true,
+				ConflictsWith: []string{"smtp_password"},
+				Description:   "Write-only SMTP password that is never stored in the state. It is only sent on create and when `smtp_password_version` changes.",
+			},
+
+			"smtp_password_version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Change this value to set the SMTP password again, for example to rotate `smtp_password_wo` or to restore a password changed outside of Terraform.",
 			},
 
 			"wildcard": {
 				Type:     schema.TypeBool,
-				ForceNew: true,
 				Optional: true,
 			},
 
 			"dkim_selector": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 			},
 
 			"force_dkim_authority": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				ForceNew: true,
 			},
 
 			"open_tracking": {
@@ -83,10 +106,54 @@ func resourceMailgunDomain() *schema.Resource {
 			},
 
 			"click_tracking": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				ForceNew: false,
-				Default:  false,
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ForceNew:      false,
+				Default:       false,
+				Deprecated:    "Use `click_tracking_mode` instead.",
+				ConflictsWith: []string{"click_tracking_mode"},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// click_tracking follows click_tracking_mode when the
+					// latter is configured.
+					return isConfigured(d, "click_tracking_mode")
+				},
+			},
+
+			"click_tracking_mode": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.StringInSlice([]string{clickTrackingYes, clickTrackingNo, clickTrackingHTMLOnly}, false),
+				ConflictsWith: []string{"click_tracking"},
+				Description:   "Click tracking mode: `yes`, `no` or `htmlonly` to only rewrite links in HTML parts.",
+			},
+
+			"unsubscribe_tracking": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Unsubscribe tracking settings. Mailgun's defaults are kept when the block is omitted.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"active": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+						"html_footer": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Footer appended to HTML messages. `%unsubscribe_url%` is replaced with the unsubscribe link.",
+						},
+						"text_footer": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Footer appended to plain text messages. `%unsubscribe_url%` is replaced with the unsubscribe link.",
+						},
+					},
+				},
 			},
 
 			"web_scheme": {
@@ -96,6 +163,27 @@ func resourceMailgunDomain() *schema.Resource {
 				Default:  "http",
 			},
 
+			"require_tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only deliver messages of the domain over TLS; delivery fails if the receiving server doesn't support it.",
+			},
+
+			"skip_verification": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Don't verify the certificate and hostname of the receiving server when delivering over TLS.",
+			},
+
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse to delete the domain, including replacing it, until this is set to false and applied.",
+			},
+
 			"use_automatic_sender_security": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -103,6 +191,23 @@ func resourceMailgunDomain() *schema.Resource {
 				Description: "Enable Mailgun's automatic sender security enforcement for the domain.",
 			},
 
+			"ips": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Computed:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"ip_pool_id"},
+				Description:   "Dedicated IPs the domain sends from. When omitted, the IPs Mailgun assigned are left untouched.",
+			},
+
+			"ip_pool_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"ips"},
+				Description:   "ID of the IP pool the domain sends from. When omitted, the pool Mailgun has linked is left untouched.",
+			},
+
 			"receiving_records": {
 				Type:       schema.TypeList,
 				Computed:   true,
@@ -227,6 +332,51 @@ func resourceMailgunDomain() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+
+			"dkim_rotation_selector": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Selector of the DKIM key to rotate to. Setting a new selector creates a key whose DNS record is exposed in `dkim_rotation_record`; the key is activated once Mailgun reports the record as valid.",
+			},
+
+			"dkim_rotation_key_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      2048,
+				ValidateFunc: validation.IntInSlice([]int{1024, 2048}),
+				Description:  "Size of the DKIM key created for `dkim_rotation_selector`.",
+			},
+
+			"dkim_rotation_record": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"record_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"valid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"dkim_active_selector": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Selector of the DKIM key used to sign messages, only tracked while `dkim_rotation_selector` is set.",
+			},
 		},
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
@@ -253,10 +403,51 @@ func resourceMailgunDomain() *schema.Resource {
 
 				return nil
 			},
+			// The selector is part of the DKIM record name, so changing it in
+			// place changes the sending records.
+			customdiff.If(
+				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) bool {
+					return diff.Id() != "" && diff.HasChange("dkim_selector") && diff.Get("dkim_selector").(string) != ""
+				},
+				func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+					if err := diff.SetNewComputed("sending_records"); err != nil {
+						return err
+					}
+					return diff.SetNewComputed("sending_records_set")
+				},
+			),
+			customizeDiffDKIMRotation,
 		),
 	}
 }
 
+// customizeDiffDKIMRotation plans the DNS record of a new rotation key, and
+// the activation of the key once the record validated.
+func customizeDiffDKIMRotation(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	selector := diff.Get("dkim_rotation_selector").(string)
+	if diff.Id() == "" || selector == "" {
+		return nil
+	}
+
+	if diff.HasChange("dkim_rotation_selector") {
+		if err := diff.SetNewComputed("dkim_rotation_record"); err != nil {
+			return err
+		}
+		return diff.SetNewComputed("dkim_active_selector")
+	}
+
+	if diff.Get("dkim_active_selector").(string) == selector {
+		return nil
+	}
+
+	if diff.Get("dkim_rotation_record.0.valid").(string) == dkimRecordValid {
+		log.Printf("[INFO] DKIM record of selector %s is valid, planning activation", selector)
+		return diff.SetNew("dkim_active_selector", selector)
+	}
+
+	return nil
+}
+
 func domainRecordsSchemaSetFunc(v interface{}) int {
 	m, ok := v.(map[string]interface{})
 
@@ -273,43 +464,40 @@ func domainRecordsSchemaSetFunc(v interface{}) int {
 
 func resourceMailgunDomainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
-	setDefaultRegionForImport(d)
+	setDefaultRegionForImport(d, meta)
+	setDefaultSubaccount(d, meta)
+	_ = d.Set("deletion_protection", false)
 
 	return []*schema.ResourceData{d}, nil
 }
 
 func resourceMailgunDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var name = d.Get("name").(string)
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
 
-	var currentData schema.ResourceData
-	var newPassword = d.Get("smtp_password").(string)
 	var smtpLogin = d.Get("smtp_login").(string)
 	var openTracking = d.Get("open_tracking").(bool)
-	var clickTracking = d.Get("click_tracking").(bool)
 	var webScheme = d.Get("web_scheme").(string)
 	var autoSenderSecurity = d.Get("use_automatic_sender_security").(bool)
 
-	// Retrieve and update state of domain
-	_, errc = resourceMailgunDomainRetrieve(d.Id(), client, &currentData)
-
-	if errc != nil {
-		return diag.FromErr(errc)
-	}
-
-	// Update default credential if changed
-	if currentData.Get("smtp_password") != newPassword {
-		errc = client.ChangeCredentialPassword(ctx, name, smtpLogin, newPassword)
-
-		if errc != nil {
-			return diag.FromErr(errc)
+	// Mailgun never returns the password, so it is only sent again when the
+	// configuration changes or smtp_password_version is bumped.
+	if d.HasChanges("smtp_password", "smtp_password_version") {
+		if newPassword := domainSMTPPassword(d); newPassword != "" {
+			errc = client.ChangeCredentialPassword(ctx, name, smtpLogin, newPassword)
+
+			if errc != nil {
+				return diag.FromErr(errc)
+			}
+		} else {
+			log.Printf("[WARN] No SMTP password configured for domain %s, keeping the current one", name)
 		}
 	}
 
-	if currentData.Get("open_tracking") != openTracking {
+	if d.HasChange("open_tracking") {
 		var openTrackingValue = "no"
 		if openTracking {
 			openTrackingValue = "yes"
@@ -321,43 +509,203 @@ func resourceMailgunDomainUpdate(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
-	if currentData.Get("click_tracking") != clickTracking {
-		var clickTrackingValue = "no"
-		if clickTracking {
-			clickTrackingValue = "yes"
+	if d.HasChanges("click_tracking", "click_tracking_mode") {
+		errc = client.UpdateClickTracking(ctx, name, clickTrackingMode(d))
+
+		if errc != nil {
+			return diag.FromErr(errc)
 		}
-		errc = client.UpdateClickTracking(ctx, d.Get("name").(string), clickTrackingValue)
+	}
+
+	if _, ok := d.GetOk("unsubscribe_tracking"); ok && d.HasChange("unsubscribe_tracking") {
+		errc = updateUnsubscribeTracking(ctx, client, name, d)
 
 		if errc != nil {
 			return diag.FromErr(errc)
 		}
 	}
 
-	updateOpts := &mailgun.UpdateDomainOptions{}
+	updateOpts := &domainUpdateOptions{}
 	var needsUpdate bool
 
-	if currentData.Get("web_scheme") != webScheme {
+	if d.HasChange("web_scheme") {
 		updateOpts.WebScheme = webScheme
 		needsUpdate = true
 	}
 
-	if currentAuto, ok := currentData.Get("use_automatic_sender_security").(bool); !ok || currentAuto != autoSenderSecurity {
+	if d.HasChange("use_automatic_sender_security") {
 		updateOpts.UseAutomaticSenderSecurity = &autoSenderSecurity
 		needsUpdate = true
 	}
 
+	if d.HasChange("require_tls") {
+		requireTLS := d.Get("require_tls").(bool)
+		updateOpts.RequireTLS = &requireTLS
+		needsUpdate = true
+	}
+
+	if d.HasChange("skip_verification") {
+		skipVerification := d.Get("skip_verification").(bool)
+		updateOpts.SkipVerification = &skipVerification
+		needsUpdate = true
+	}
+
+	if d.HasChange("spam_action") {
+		updateOpts.SpamAction = mtypes.SpamAction(d.Get("spam_action").(string))
+		needsUpdate = true
+	}
+
+	if d.HasChange("wildcard") {
+		wildcard := d.Get("wildcard").(bool)
+		updateOpts.Wildcard = &wildcard
+		needsUpdate = true
+	}
+
+	if d.HasChange("force_dkim_authority") {
+		forceDKIMAuthority := d.Get("force_dkim_authority").(bool)
+		updateOpts.ForceDKIMAuthority = &forceDKIMAuthority
+		needsUpdate = true
+	}
+
 	if needsUpdate {
-		errc = client.UpdateDomain(ctx, name, updateOpts)
+		errc = updateDomain(ctx, client, name, updateOpts)
 		if errc != nil {
 			return diag.FromErr(errc)
 		}
 	}
 
+	if _, ok := d.GetOk("ips"); ok && d.HasChange("ips") {
+		errc = updateDomainIPs(ctx, client, name, d)
+
+		if errc != nil {
+			return diag.FromErr(errc)
+		}
+	}
+
+	if d.HasChange("ip_pool_id") {
+		oldPoolID, newPoolID := d.GetChange("ip_pool_id")
+
+		if oldPoolID.(string) != "" {
+			log.Printf("[INFO] Unlinking IP pool %s from domain %s", oldPoolID, name)
+			errc = unlinkDomainIPPool(ctx, client, name, newPoolID.(string))
+		} else {
+			log.Printf("[INFO] Linking IP pool %s to domain %s", newPoolID, name)
+			errc = linkDomainIPPool(ctx, client, name, newPoolID.(string))
+		}
+
+		if errc != nil {
+			return diag.FromErr(errc)
+		}
+	}
+
+	if selector := d.Get("dkim_rotation_selector").(string); selector != "" && d.HasChanges("dkim_rotation_selector", "dkim_active_selector") {
+		if d.HasChange("dkim_rotation_selector") {
+			errc = rotateDomainDKIMKey(ctx, client, name, selector, d.Get("dkim_rotation_key_size").(int), replacedDKIMSelectors(d))
+		} else {
+			errc = activateDomainDKIMKey(ctx, client, name, selector, replacedDKIMSelectors(d))
+		}
+
+		if errc != nil {
+			return diag.FromErr(errc)
+		}
+
+		if _, errc = resourceMailgunDomainRetrieve(ctx, name, client, d); errc != nil {
+			return diag.FromErr(errc)
+		}
+	}
+
+	// Mailgun can't reset the selector, so removing dkim_selector keeps the
+	// current one.
+	if dkimSelector := d.Get("dkim_selector").(string); d.HasChange("dkim_selector") && dkimSelector != "" {
+		errc = client.UpdateDomainDkimSelector(ctx, name, dkimSelector)
+		if errc != nil {
+			return diag.FromErr(errc)
+		}
+
+		if _, errc = resourceMailgunDomainRetrieve(ctx, name, client, d); errc != nil {
+			return diag.FromErr(errc)
+		}
+	}
+
+	return nil
+}
+
+// domainUpdateOptions extends mailgun.UpdateDomainOptions with the domain
+// settings the SDK can't update.
+type domainUpdateOptions struct {
+	mailgun.UpdateDomainOptions
+
+	SpamAction         mtypes.SpamAction
+	Wildcard           *bool
+	ForceDKIMAuthority *bool
+}
+
+// updateDomain updates the domain settings set in opts through the v4
+// domains API.
+func updateDomain(ctx context.Context, client *mailgun.Client, name string, opts *domainUpdateOptions) error {
+	form := url.Values{}
+
+	if opts.WebScheme != "" {
+		form.Set("web_scheme", opts.WebScheme)
+	}
+	if opts.WebPrefix != "" {
+		form.Set("web_prefix", opts.WebPrefix)
+	}
+	if opts.RequireTLS != nil {
+		form.Set("require_tls", strconv.FormatBool(*opts.RequireTLS))
+	}
+	if opts.SkipVerification != nil {
+		form.Set("skip_verification", strconv.FormatBool(*opts.SkipVerification))
+	}
+	if opts.UseAutomaticSenderSecurity != nil {
+		form.Set("use_automatic_sender_security", strconv.FormatBool(*opts.UseAutomaticSenderSecurity))
+	}
+	if opts.SpamAction != "" {
+		form.Set("spam_action", string(opts.SpamAction))
+	}
+	if opts.Wildcard != nil {
+		form.Set("wildcard", strconv.FormatBool(*opts.Wildcard))
+	}
+	if opts.ForceDKIMAuthority != nil {
+		form.Set("force_dkim_authority", strconv.FormatBool(*opts.ForceDKIMAuthority))
+	}
+
+	log.Printf("[DEBUG] Domain update configuration: %s", form.Encode())
+
+	return apiRequest(ctx, client, http.MethodPut, "/v4/domains/"+url.PathEscape(name), form, nil)
+}
+
+// updateDomainIPs assigns the configured ips to the domain and unassigns the
+// others. New IPs are assigned first so the domain always has an IP to send
+// from.
+func updateDomainIPs(ctx context.Context, client *mailgun.Client, name string, d *schema.ResourceData) error {
+	o, n := d.GetChange("ips")
+	oldIPs, newIPs := o.(*schema.Set), n.(*schema.Set)
+
+	for _, ip := range newIPs.Difference(oldIPs).List() {
+		log.Printf("[INFO] Assigning IP %s to domain %s", ip, name)
+
+		if err := client.AddDomainIP(ctx, name, ip.(string)); err != nil {
+			return fmt.Errorf("Error assigning IP %s: %w", ip, err)
+		}
+	}
+
+	for _, ip := range oldIPs.Difference(newIPs).List() {
+		log.Printf("[INFO] Unassigning IP %s from domain %s", ip, name)
+
+		if err := client.DeleteDomainIP(ctx, name, ip.(string)); err != nil && !isNotFound(err) {
+			return fmt.Errorf("Error unassigning IP %s: %w", ip, err)
+		}
+	}
+
 	return nil
 }
 
 func resourceMailgunDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -367,24 +715,37 @@ func resourceMailgunDomainCreate(ctx context.Context, d *schema.ResourceData, me
 	name := d.Get("name").(string)
 
 	opts.SpamAction = mtypes.SpamAction(d.Get("spam_action").(string))
-	opts.Password = d.Get("smtp_password").(string)
+	opts.Password = domainSMTPPassword(d)
 	opts.Wildcard = d.Get("wildcard").(bool)
 	opts.DKIMKeySize = d.Get("dkim_key_size").(int)
 	opts.ForceDKIMAuthority = d.Get("force_dkim_authority").(bool)
 	opts.WebScheme = d.Get("web_scheme").(string)
 	opts.UseAutomaticSenderSecurity = d.Get("use_automatic_sender_security").(bool)
+	if v, ok := d.GetOk("ips"); ok {
+		opts.IPs = expandStringSet(v.(*schema.Set))
+	}
 	var dkimSelector = d.Get("dkim_selector").(string)
 	var openTracking = d.Get("open_tracking").(bool)
-	var clickTracking = d.Get("click_tracking").(bool)
+	var clickTracking = clickTrackingMode(d)
 
-	log.Printf("[DEBUG] Domain create configuration: %#v", opts)
+	// The SMTP password may come from smtp_password_wo, so it's left out of
+	// the log.
+	logOpts := opts
+	logOpts.Password = ""
+	log.Printf("[DEBUG] Domain create configuration: %#v", logOpts)
 
-	_, err := client.CreateDomain(context.Background(), name, &opts)
+	_, err := client.CreateDomain(ctx, name, &opts)
 
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	// Store the domain right away, so it stays managed if one of the
+	// settings below fails.
+	d.SetId(name)
+
+	log.Printf("[INFO] Domain ID: %s", d.Id())
+
 	if dkimSelector != "" {
 		errc = client.UpdateDomainDkimSelector(ctx, name, dkimSelector)
 
@@ -399,30 +760,72 @@ func resourceMailgunDomainCreate(ctx context.Context, d *schema.ResourceData, me
 			return diag.FromErr(errc)
 		}
 	}
-	if clickTracking {
-		errc = client.UpdateClickTracking(ctx, d.Get("name").(string), "yes")
+	if clickTracking != clickTrackingNo {
+		errc = client.UpdateClickTracking(ctx, d.Get("name").(string), clickTracking)
 
 		if errc != nil {
 			return diag.FromErr(errc)
 		}
 	}
 
-	d.SetId(name)
+	if _, ok := d.GetOk("unsubscribe_tracking"); ok {
+		errc = updateUnsubscribeTracking(ctx, client, name, d)
 
-	log.Printf("[INFO] Domain ID: %s", d.Id())
+		if errc != nil {
+			return diag.FromErr(errc)
+		}
+	}
+
+	if selector := d.Get("dkim_rotation_selector").(string); selector != "" {
+		errc = rotateDomainDKIMKey(ctx, client, name, selector, d.Get("dkim_rotation_key_size").(int), replacedDKIMSelectors(d))
+
+		if errc != nil {
+			return diag.FromErr(errc)
+		}
+	}
+
+	if poolID := d.Get("ip_pool_id").(string); poolID != "" {
+		errc = linkDomainIPPool(ctx, client, name, poolID)
+
+		if errc != nil {
+			return diag.FromErr(errc)
+		}
+	}
+
+	// The connection settings can't be passed when creating the domain.
+	if requireTLS, skipVerification := d.Get("require_tls").(bool), d.Get("skip_verification").(bool); requireTLS || skipVerification {
+		connectionOpts := &domainUpdateOptions{}
+		connectionOpts.RequireTLS = &requireTLS
+		connectionOpts.SkipVerification = &skipVerification
+
+		errc = updateDomain(ctx, client, name, connectionOpts)
+
+		if errc != nil {
+			return diag.FromErr(errc)
+		}
+	}
 
 	// Retrieve and update state of domain
-	_, err = resourceMailgunDomainRetrieve(d.Id(), client, d)
+	_, err = resourceMailgunDomainRetrieve(ctx, d.Id(), client, d)
 
 	if err != nil {
-		return diag.FromErr(errc)
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
 func resourceMailgunDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Domain %s has deletion protection enabled", d.Id()),
+			Detail:        "Set deletion_protection to false and apply the change before destroying or replacing the domain.",
+			AttributePath: cty.GetAttrPath("deletion_protection"),
+		}}
+	}
+
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -430,7 +833,7 @@ func resourceMailgunDomainDelete(ctx context.Context, d *schema.ResourceData, me
 	log.Printf("[INFO] Deleting Domain: %s", d.Id())
 
 	// Destroy the domain
-	err := client.DeleteDomain(context.Background(), d.Id())
+	err := client.DeleteDomain(ctx, d.Id())
 	if err != nil {
 		return diag.Errorf("Error deleting domain: %s", err)
 	}
@@ -456,26 +859,32 @@ func resourceMailgunDomainDelete(ctx context.Context, d *schema.ResourceData, me
 
 func resourceMailgunDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
 
-	_, err := resourceMailgunDomainRetrieve(d.Id(), client, d)
+	_, err := resourceMailgunDomainRetrieve(ctx, d.Id(), client, d)
 
 	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Domain %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceMailgunDomainRetrieve(id string, client *mailgun.Client, d *schema.ResourceData) (*mtypes.GetDomainResponse, error) {
+func resourceMailgunDomainRetrieve(ctx context.Context, id string, client *mailgun.Client, d *schema.ResourceData) (*mtypes.GetDomainResponse, error) {
 
-	resp, err := client.GetDomain(context.Background(), id, nil)
+	resp, poolID, err := getDomain(ctx, client, id)
 
 	if err != nil {
-		return nil, fmt.Errorf("Error retrieving domain: %s", err)
+		return nil, fmt.Errorf("Error retrieving domain: %w", err)
 	}
 
 	_ = d.Set("name", resp.Domain.Name)
@@ -484,6 +893,8 @@ func resourceMailgunDomainRetrieve(id string, client *mailgun.Client, d *schema.
 	_ = d.Set("spam_action", resp.Domain.SpamAction)
 	_ = d.Set("web_scheme", resp.Domain.WebScheme)
 	_ = d.Set("use_automatic_sender_security", resp.Domain.UseAutomaticSenderSecurity)
+	_ = d.Set("require_tls", resp.Domain.RequireTLS)
+	_ = d.Set("skip_verification", resp.Domain.SkipVerification)
 
 	receivingRecords := make([]map[string]interface{}, len(resp.ReceivingDNSRecords))
 	for i, r := range resp.ReceivingDNSRecords {
@@ -513,18 +924,275 @@ func resourceMailgunDomainRetrieve(id string, client *mailgun.Client, d *schema.
 	_ = d.Set("sending_records", sendingRecords)
 	_ = d.Set("sending_records_set", sendingRecords)
 
-	info, err := client.GetDomainTracking(context.Background(), id)
+	ips, err := client.ListDomainIPs(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving domain IPs: %s", err)
+	}
+
+	ipAddresses := make([]string, len(ips))
+	for i, ip := range ips {
+		ipAddresses[i] = ip.IP
+	}
+	_ = d.Set("ips", ipAddresses)
+
+	_ = d.Set("ip_pool_id", poolID)
+
+	if err := setDomainDKIMRotationState(ctx, client, id, d); err != nil {
+		return nil, err
+	}
+
+	info, err := getDomainTracking(ctx, client, id)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving domain tracking: %s", err)
+	}
+
 	var openTracking = false
 	if info.Open.Active {
 		openTracking = true
 	}
 	_ = d.Set("open_tracking", openTracking)
 
-	var clickTracking = false
-	if info.Click.Active {
-		clickTracking = true
+	_ = d.Set("click_tracking", info.Click.Active != clickTrackingNo)
+	_ = d.Set("click_tracking_mode", string(info.Click.Active))
+
+	_ = d.Set("unsubscribe_tracking", []map[string]interface{}{
+		{
+			"active":      info.Unsubscribe.Active,
+			"html_footer": info.Unsubscribe.HTMLFooter,
+			"text_footer": info.Unsubscribe.TextFooter,
+		},
+	})
+
+	return resp, nil
+}
+
+const dkimRecordValid = "valid"
+
+// rotateDomainDKIMKey creates the key for a new rotation selector. The key
+// is only activated right away if its DNS record already validates.
+func rotateDomainDKIMKey(ctx context.Context, client *mailgun.Client, domain, selector string, bits int, replaced []string) error {
+	log.Printf("[INFO] Creating DKIM key %s for domain %s", selector, domain)
+
+	key, err := getDomainKey(ctx, client, domain, selector)
+	if isNotFound(err) {
+		key, err = createDKIMKey(ctx, client, domain, selector, bits, "")
+	}
+	if err != nil {
+		return fmt.Errorf("Error creating DKIM key %s: %w", selector, err)
+	}
+
+	if key.DNSRecord.Valid == dkimRecordValid && !key.DNSRecord.Active {
+		return activateDomainDKIMKey(ctx, client, domain, selector, replaced)
+	}
+
+	return nil
+}
+
+// activateDomainDKIMKey activates the key of selector and deactivates the
+// active keys among replaced. Other keys, such as those managed by
+// mailgun_domain_dkim_key, are left alone. The DNS records of deactivated
+// keys remain valid, so messages signed before the rotation can still be
+// verified.
+func activateDomainDKIMKey(ctx context.Context, client *mailgun.Client, domain, selector string, replaced []string) error {
+	log.Printf("[INFO] Activating DKIM key %s for domain %s", selector, domain)
+
+	if err := activateDomainKey(ctx, client, domain, selector); err != nil {
+		return fmt.Errorf("Error activating DKIM key %s: %w", selector, err)
 	}
-	_ = d.Set("click_tracking", clickTracking)
 
-	return &resp, nil
+	keys, err := listDomainKeys(ctx, client, domain)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if key.Selector == selector || !key.DNSRecord.Active || !slices.Contains(replaced, key.Selector) {
+			continue
+		}
+
+		log.Printf("[INFO] Deactivating DKIM key %s for domain %s", key.Selector, domain)
+
+		if err := deactivateDomainKey(ctx, client, domain, key.Selector); err != nil {
+			return fmt.Errorf("Error deactivating DKIM key %s: %w", key.Selector, err)
+		}
+	}
+
+	return nil
+}
+
+// replacedDKIMSelectors returns the selectors of the keys a rotation key
+// replaces: the previous rotation key and the key of dkim_selector.
+func replacedDKIMSelectors(d *schema.ResourceData) []string {
+	var selectors []string
+
+	if old, _ := d.GetChange("dkim_rotation_selector"); old.(string) != "" {
+		selectors = append(selectors, old.(string))
+	}
+
+	if selector := d.Get("dkim_selector").(string); selector != "" {
+		selectors = append(selectors, selector)
+	}
+
+	return selectors
+}
+
+// setDomainDKIMRotationState reads the key of dkim_rotation_selector. Keys
+// are only looked up while a rotation is configured.
+func setDomainDKIMRotationState(ctx context.Context, client *mailgun.Client, domain string, d *schema.ResourceData) error {
+	selector, _ := d.Get("dkim_rotation_selector").(string)
+	if selector == "" {
+		_ = d.Set("dkim_rotation_record", nil)
+		_ = d.Set("dkim_active_selector", "")
+		return nil
+	}
+
+	keys, err := listDomainKeys(ctx, client, domain)
+	if err != nil {
+		return fmt.Errorf("Error retrieving DKIM keys: %s", err)
+	}
+
+	var record []map[string]interface{}
+	var activeSelector string
+
+	for _, key := range keys {
+		if key.Selector == selector {
+			record = []map[string]interface{}{
+				{
+					"name":        key.DNSRecord.Name,
+					"record_type": key.DNSRecord.RecordType,
+					"value":       key.DNSRecord.Value,
+					"valid":       key.DNSRecord.Valid,
+				},
+			}
+		}
+
+		if key.DNSRecord.Active && (activeSelector == "" || key.Selector == selector) {
+			activeSelector = key.Selector
+		}
+	}
+
+	_ = d.Set("dkim_rotation_record", record)
+	_ = d.Set("dkim_active_selector", activeSelector)
+
+	return nil
+}
+
+// domainSMTPPassword returns the configured SMTP password, which is either
+// smtp_password or the write-only smtp_password_wo that is only available in
+// the raw configuration.
+func domainSMTPPassword(d *schema.ResourceData) string {
+	if password := d.Get("smtp_password").(string); password != "" {
+		return password
+	}
+
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return ""
+	}
+
+	password := config.GetAttr("smtp_password_wo")
+	if password.IsNull() || !password.IsKnown() {
+		return ""
+	}
+
+	return password.AsString()
+}
+
+const (
+	clickTrackingYes      = "yes"
+	clickTrackingNo       = "no"
+	clickTrackingHTMLOnly = "htmlonly"
+)
+
+// clickTrackingMode returns the click tracking mode to apply, taken from
+// click_tracking_mode if it is configured and from the deprecated
+// click_tracking otherwise.
+func clickTrackingMode(d *schema.ResourceData) string {
+	if isConfigured(d, "click_tracking_mode") {
+		return d.Get("click_tracking_mode").(string)
+	}
+
+	if d.Get("click_tracking").(bool) {
+		return clickTrackingYes
+	}
+
+	return clickTrackingNo
+}
+
+// clickTrackingActive holds the click tracking mode, which Mailgun reports
+// either as a boolean or as the string "htmlonly".
+type clickTrackingActive string
+
+func (a *clickTrackingActive) UnmarshalJSON(data []byte) error {
+	var active interface{}
+	if err := json.Unmarshal(data, &active); err != nil {
+		return err
+	}
+
+	switch v := active.(type) {
+	case bool:
+		*a = clickTrackingNo
+		if v {
+			*a = clickTrackingYes
+		}
+	case string:
+		switch v {
+		case "true", clickTrackingYes:
+			*a = clickTrackingYes
+		case "false", clickTrackingNo, "":
+			*a = clickTrackingNo
+		default:
+			*a = clickTrackingActive(v)
+		}
+	default:
+		return fmt.Errorf("unexpected click tracking value %s", data)
+	}
+
+	return nil
+}
+
+// domainTracking is mtypes.DomainTracking with a click tracking mode that
+// supports "htmlonly", which the SDK fails to decode.
+type domainTracking struct {
+	Click struct {
+		Active clickTrackingActive `json:"active"`
+	} `json:"click"`
+	Open        mtypes.TrackingStatus `json:"open"`
+	Unsubscribe mtypes.TrackingStatus `json:"unsubscribe"`
+}
+
+func getDomainTracking(ctx context.Context, client *mailgun.Client, name string) (*domainTracking, error) {
+	var resp struct {
+		Tracking domainTracking `json:"tracking"`
+	}
+
+	if err := apiRequest(ctx, client, http.MethodGet, "/v3/domains/"+url.PathEscape(name)+"/tracking", nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp.Tracking, nil
+}
+
+// updateUnsubscribeTracking applies the unsubscribe_tracking block. Footers
+// left out of the block keep their current value.
+func updateUnsubscribeTracking(ctx context.Context, client *mailgun.Client, name string, d *schema.ResourceData) error {
+	tracking := d.Get("unsubscribe_tracking.0").(map[string]interface{})
+
+	var active = "no"
+	if tracking["active"].(bool) {
+		active = "yes"
+	}
+
+	// The SDK always sends both footers, which would clear Mailgun's
+	// default footers when they aren't configured.
+	form := url.Values{"active": {active}}
+	for _, key := range []string{"html_footer", "text_footer"} {
+		if footer := tracking[key].(string); footer != "" {
+			form.Set(key, footer)
+		}
+	}
+
+	log.Printf("[DEBUG] Updating unsubscribe tracking of domain %s: active: %s", name, active)
+
+	return apiRequest(ctx, client, http.MethodPut, "/v3/domains/"+url.PathEscape(name)+"/tracking/unsubscribe", form, nil)
 }
@@ -0,0 +1,267 @@
+package mailgun
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMailgunDomainDkimKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMailgunDomainDkimKeyCreate,
+		ReadContext:   resourceMailgunDomainDkimKeyRead,
+		UpdateContext: resourceMailgunDomainDkimKeyUpdate,
+		DeleteContext: resourceMailgunDomainDkimKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMailgunDomainDkimKeyImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account": accountSchema("the DKIM key"),
+
+			"region": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Computed: true,
+			},
+
+			"subaccount_id": subaccountSchema("the DKIM key"),
+
+			"domain": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The Mailgun domain whose messages are signed with the key.",
+			},
+
+			"selector": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"signing_domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The domain the key signs for, the `d=` tag of the signature. Defaults to `domain`.",
+			},
+
+			"bits": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.IntInSlice([]int{1024, 2048}),
+				ConflictsWith:    []string{"private_key_pem"},
+				DiffSuppressFunc: suppressUnreadableKeyDiff,
+				Description:      "Size of the key Mailgun generates.",
+			},
+
+			"private_key_pem": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				ConflictsWith:    []string{"bits"},
+				DiffSuppressFunc: suppressUnreadableKeyDiff,
+				Description:      "PEM encoded private key to import instead of letting Mailgun generate one.",
+			},
+
+			"active": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether messages are signed with the key. Only activate it once the DNS record is published.",
+			},
+
+			"dns_record_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dns_record_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"dns_record_value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"valid": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMailgunDomainDkimKeyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if strings.Count(d.Id(), ":") == 2 {
+		setDefaultRegionForImport(d, meta)
+	} else {
+		setAccountForImport(d, meta)
+		setDefaultRegion(d, meta)
+	}
+	setDefaultSubaccount(d, meta)
+
+	domain, selector, err := parseDomainDkimKeyID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	_ = d.Set("domain", domain)
+	_ = d.Set("selector", selector)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceMailgunDomainDkimKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	domain := d.Get("domain").(string)
+	selector := d.Get("selector").(string)
+
+	signingDomain := d.Get("signing_domain").(string)
+	if signingDomain == "" {
+		signingDomain = domain
+	}
+
+	log.Printf("[DEBUG] DKIM key create configuration: domain: %s, signing domain: %s, selector: %s", domain, signingDomain, selector)
+
+	_, err := createDKIMKey(ctx, client, signingDomain, selector, d.Get("bits").(int), d.Get("private_key_pem").(string))
+	if err != nil {
+		return diag.Errorf("Error creating DKIM key: %s", err)
+	}
+
+	d.SetId(domain + ":" + selector)
+
+	log.Printf("[INFO] DKIM key ID: %s", d.Id())
+
+	if d.Get("active").(bool) {
+		if err := activateDomainKey(ctx, client, domain, selector); err != nil {
+			return diag.Errorf("Error activating DKIM key: %s", err)
+		}
+	}
+
+	return resourceMailgunDomainDkimKeyRead(ctx, d, meta)
+}
+
+func resourceMailgunDomainDkimKeyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	domain := d.Get("domain").(string)
+	selector := d.Get("selector").(string)
+
+	if d.HasChange("active") {
+		var err error
+		if d.Get("active").(bool) {
+			log.Printf("[INFO] Activating DKIM key: %s", d.Id())
+			err = activateDomainKey(ctx, client, domain, selector)
+		} else {
+			log.Printf("[INFO] Deactivating DKIM key: %s", d.Id())
+			err = deactivateDomainKey(ctx, client, domain, selector)
+		}
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceMailgunDomainDkimKeyRead(ctx, d, meta)
+}
+
+func resourceMailgunDomainDkimKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	domain := d.Get("domain").(string)
+	selector := d.Get("selector").(string)
+
+	if d.Get("active").(bool) {
+		log.Printf("[INFO] Deactivating DKIM key before deleting it: %s", d.Id())
+
+		if err := deactivateDomainKey(ctx, client, domain, selector); err != nil && !isNotFound(err) {
+			return diag.Errorf("Error deactivating DKIM key: %s", err)
+		}
+	}
+
+	log.Printf("[INFO] Deleting DKIM key: %s", d.Id())
+
+	if err := deleteDKIMKey(ctx, client, d.Get("signing_domain").(string), selector); err != nil && !isNotFound(err) {
+		return diag.Errorf("Error deleting DKIM key: %s", err)
+	}
+
+	return nil
+}
+
+func resourceMailgunDomainDkimKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	domain, selector, err := parseDomainDkimKeyID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	key, err := getDomainKey(ctx, client, domain, selector)
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] DKIM key %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("domain", domain)
+	_ = d.Set("selector", key.Selector)
+	_ = d.Set("signing_domain", key.SigningDomain)
+	_ = d.Set("active", key.DNSRecord.Active)
+	_ = d.Set("dns_record_name", key.DNSRecord.Name)
+	_ = d.Set("dns_record_type", key.DNSRecord.RecordType)
+	_ = d.Set("dns_record_value", key.DNSRecord.Value)
+	_ = d.Set("valid", key.DNSRecord.Valid)
+
+	return nil
+}
+
+// suppressUnreadableKeyDiff ignores bits and private_key_pem on imported keys.
+// Mailgun doesn't return them, and the key of an existing selector can't be
+// changed anyway.
+func suppressUnreadableKeyDiff(k, old, new string, d *schema.ResourceData) bool {
+	return d.Id() != "" && (old == "" || old == "0")
+}
+
+// parseDomainDkimKeyID splits a domain:selector ID.
+func parseDomainDkimKeyID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid DKIM key ID %q, expected domain:selector", id)
+	}
+
+	return parts[0], parts[1], nil
+}
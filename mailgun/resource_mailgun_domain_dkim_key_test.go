@@ -0,0 +1,124 @@
+package mailgun
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMailgunDomainDkimKey_Basic(t *testing.T) {
+	uuid, _ := uuid.GenerateUUID()
+	domain := fmt.Sprintf("terraform.%s.com", uuid)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunDomainDkimKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunDomainDkimKeyConfig(domain, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"mailgun_domain_dkim_key.foobar", "id", domain+":tfkey"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain_dkim_key.foobar", "signing_domain", domain),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain_dkim_key.foobar", "active", "false"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain_dkim_key.foobar", "dns_record_name", "tfkey._domainkey."+domain),
+					resource.TestCheckResourceAttrSet(
+						"mailgun_domain_dkim_key.foobar", "dns_record_value"),
+				),
+			},
+			{
+				Config: testAccCheckMailgunDomainDkimKeyConfig(domain, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"mailgun_domain_dkim_key.foobar", "active", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMailgunDomainDkimKey_Import(t *testing.T) {
+	resourceName := "mailgun_domain_dkim_key.foobar"
+	uuid, _ := uuid.GenerateUUID()
+	domain := fmt.Sprintf("terraform.%s.com", uuid)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunDomainDkimKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunDomainDkimKeyConfig(domain, false),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateId:           "us:" + domain + ":tfkey",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"bits", "private_key_pem"},
+			},
+		},
+	})
+}
+
+func TestParseDomainDkimKeyID(t *testing.T) {
+	t.Parallel()
+
+	domain, selector, err := parseDomainDkimKeyID("example.com:s1")
+	if err != nil || domain != "example.com" || selector != "s1" {
+		t.Fatalf("unexpected result %q, %q, %v", domain, selector, err)
+	}
+
+	for _, id := range []string{"example.com", ":s1", "example.com:"} {
+		if _, _, err := parseDomainDkimKeyID(id); err == nil {
+			t.Fatalf("%q: expected an error", id)
+		}
+	}
+}
+
+func testAccCheckMailgunDomainDkimKeyDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mailgun_domain_dkim_key" {
+			continue
+		}
+
+		client, errc := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
+		if errc != nil {
+			return errc
+		}
+
+		_, err := getDomainKey(context.Background(), client, rs.Primary.Attributes["domain"], rs.Primary.Attributes["selector"])
+		if err == nil {
+			return fmt.Errorf("DKIM key still exists: %s", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckMailgunDomainDkimKeyConfig(domain string, active bool) string {
+	return fmt.Sprintf(`
+resource "mailgun_domain" "foobar" {
+	name   = "%s"
+	region = "us"
+}
+
+resource "mailgun_domain_dkim_key" "foobar" {
+	domain   = mailgun_domain.foobar.name
+	region   = "us"
+	selector = "tfkey"
+	bits     = 2048
+	active   = %t
+}`, domain, active)
+}
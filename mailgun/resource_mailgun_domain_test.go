@@ -2,12 +2,20 @@ package mailgun
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/mailgun/mailgun-go/v5/mtypes"
 )
@@ -52,12 +60,369 @@ func TestAccMailgunDomain_Basic(t *testing.T) {
 						"mailgun_domain.foobar", "sending_records_set.0.name", re),
 					resource.TestCheckResourceAttr(
 						"mailgun_domain.foobar", "use_automatic_sender_security", "true"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "require_tls", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMailgunDomain_UpdateInPlace(t *testing.T) {
+	var resp mtypes.GetDomainResponse
+	var createdAt mtypes.RFC2822Time
+	uuid, _ := uuid.GenerateUUID()
+	domain := fmt.Sprintf("terraform.%s.com", uuid)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunDomainConfig(domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMailgunDomainExists("mailgun_domain.foobar", &resp),
+					func(s *terraform.State) error {
+						createdAt = resp.Domain.CreatedAt
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccCheckMailgunDomainConfigUpdated(domain),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMailgunDomainExists("mailgun_domain.foobar", &resp),
+					// The ID is the domain name, so a replaced domain is only
+					// told apart by its creation time.
+					func(s *terraform.State) error {
+						if !time.Time(resp.Domain.CreatedAt).Equal(time.Time(createdAt)) {
+							return fmt.Errorf("Domain was replaced instead of updated in place: created at %s, previously %s",
+								time.Time(resp.Domain.CreatedAt), time.Time(createdAt))
+						}
+						return nil
+					},
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "spam_action", "tag"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "wildcard", "false"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "force_dkim_authority", "false"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "dkim_selector", "tfupdated"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "require_tls", "true"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "skip_verification", "true"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "unsubscribe_tracking.0.active", "true"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "click_tracking_mode", "htmlonly"),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "unsubscribe_tracking.0.text_footer", "Unsubscribe: %unsubscribe_url%"),
+					func(s *terraform.State) error {
+						if resp.Domain.SpamAction != "tag" || resp.Domain.Wildcard || !resp.Domain.RequireTLS || !resp.Domain.SkipVerification {
+							return fmt.Errorf("Domain not updated: %#v", resp.Domain)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestResourceMailgunDomain_UpdatableInPlace(t *testing.T) {
+	s := resourceMailgunDomain().Schema
+
+	for _, key := range []string{"spam_action", "wildcard", "dkim_selector", "force_dkim_authority"} {
+		if s[key].ForceNew {
+			t.Fatalf("expected %s to be updated without replacing the domain", key)
+		}
+	}
+}
+
+func TestAccMailgunDomain_SMTPPassword(t *testing.T) {
+	uuid, _ := uuid.GenerateUUID()
+	domain := fmt.Sprintf("terraform.%s.com", uuid)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunDomainConfigWith(domain, `smtp_password = "Password-1"`),
+			},
+			// Mailgun never returns the password, which must not cause a diff.
+			{
+				Config:   testAccCheckMailgunDomainConfigWith(domain, `smtp_password = "Password-1"`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccCheckMailgunDomainConfigWith(domain, `
+	smtp_password_wo      = "Password-2"
+	smtp_password_version = 1`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mailgun_domain.foobar", "smtp_password_wo"),
+					resource.TestCheckResourceAttr("mailgun_domain.foobar", "smtp_password_version", "1"),
+				),
+			},
+			// The write-only password changes without a version bump are
+			// ignored.
+			{
+				Config: testAccCheckMailgunDomainConfigWith(domain, `
+	smtp_password_wo      = "Password-3"
+	smtp_password_version = 1`),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccCheckMailgunDomainConfigWith(domain, `
+	smtp_password_wo      = "Password-3"
+	smtp_password_version = 2`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mailgun_domain.foobar", "smtp_password_wo"),
+					resource.TestCheckResourceAttr("mailgun_domain.foobar", "smtp_password_version", "2"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccMailgunDomain_DeletionProtection(t *testing.T) {
+	uuid, _ := uuid.GenerateUUID()
+	domain := fmt.Sprintf("terraform.%s.com", uuid)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunDomainConfigWith(domain, `deletion_protection = true`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mailgun_domain.foobar", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccCheckMailgunDomainConfigWith(domain, `deletion_protection = true`),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("has deletion protection enabled"),
+			},
+			{
+				Config: testAccCheckMailgunDomainConfigWith(domain, `deletion_protection = false`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mailgun_domain.foobar", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceMailgunDomainDelete_DeletionProtection(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMailgunDomain().Schema, map[string]interface{}{
+		"name":                "example.com",
+		"deletion_protection": true,
+	})
+	d.SetId("example.com")
+
+	diags := resourceMailgunDomainDelete(context.Background(), d, nil)
+	if !diags.HasError() {
+		t.Fatal("expected deleting a protected domain to fail")
+	}
+
+	if d.Id() != "example.com" {
+		t.Fatalf("expected the domain to stay in state, got ID %q", d.Id())
+	}
+}
+
+func TestAccMailgunDomain_IPPool(t *testing.T) {
+	poolID := os.Getenv("MAILGUN_TEST_IP_POOL_ID")
+	if poolID == "" {
+		t.Skip("MAILGUN_TEST_IP_POOL_ID must be set to the ID of an IP pool")
+	}
+
+	uuid, _ := uuid.GenerateUUID()
+	domain := fmt.Sprintf("terraform.%s.com", uuid)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunDomainConfigWith(domain, fmt.Sprintf(`ip_pool_id = %q`, poolID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mailgun_domain.foobar", "ip_pool_id", poolID),
+				),
+			},
+			// Removing ip_pool_id leaves the linked pool untouched.
+			{
+				Config:   testAccCheckMailgunDomainConfig(domain),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestResourceMailgunDomainCreate_KeepsPartialDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v4/domains":
+			_, _ = w.Write([]byte(`{"domain": {"name": "example.com"}, "receiving_dns_records": [], "sending_dns_records": []}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceMailgunDomain().Schema, map[string]interface{}{
+		"name":          "example.com",
+		"region":        "us",
+		"dkim_selector": "s1",
+	})
+
+	diags := resourceMailgunDomainCreate(context.Background(), d, &Config{APIKey: "key", APIBaseURL: server.URL})
+	if !diags.HasError() {
+		t.Fatal("expected setting the DKIM selector to fail")
+	}
+
+	if d.Id() != "example.com" {
+		t.Fatalf("expected the created domain to be kept in state, got ID %q", d.Id())
+	}
+}
+
+func TestResourceMailgunDomainRead_KeepsDomainOnSubresourceNotFound(t *testing.T) {
+	cases := map[string]struct {
+		path   string
+		config map[string]interface{}
+	}{
+		"ips": {path: "/v3/domains/example.com/ips"},
+		"dkim keys": {
+			path:   "/v4/domains/example.com/keys",
+			config: map[string]interface{}{"dkim_rotation_selector": "s2"},
+		},
+		"tracking": {path: "/v3/domains/example.com/tracking"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.URL.Path {
+				case tc.path:
+					w.WriteHeader(http.StatusNotFound)
+				case "/v4/domains/example.com":
+					_, _ = w.Write([]byte(`{"domain": {"name": "example.com"}, "receiving_dns_records": [], "sending_dns_records": []}`))
+				case "/v3/domains/example.com/ips":
+					_, _ = w.Write([]byte(`{"items": [], "total_count": 0}`))
+				case "/v4/domains/example.com/keys":
+					_, _ = w.Write([]byte(`{"items": []}`))
+				case "/v3/domains/example.com/tracking":
+					_, _ = w.Write([]byte(`{"tracking": {"open": {"active": false}, "click": {"active": false}, "unsubscribe": {"active": false}}}`))
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer server.Close()
+
+			raw := map[string]interface{}{"name": "example.com", "region": "us"}
+			for k, v := range tc.config {
+				raw[k] = v
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceMailgunDomain().Schema, raw)
+			d.SetId("example.com")
+
+			diags := resourceMailgunDomainRead(context.Background(), d, &Config{APIKey: "key", APIBaseURL: server.URL})
+			if !diags.HasError() {
+				t.Fatal("expected reading the domain to fail")
+			}
+
+			if d.Id() != "example.com" {
+				t.Fatal("expected the domain to be kept in state")
+			}
+		})
+	}
+}
+
+func TestActivateDomainDKIMKey_DeactivatesReplacedKeysOnly(t *testing.T) {
+	var mu sync.Mutex
+	var deactivated []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/v4/domains/example.com/keys/s3/activate":
+			_, _ = w.Write([]byte(`{"message": "success"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v4/domains/example.com/keys":
+			_, _ = w.Write([]byte(`{"items": [
+				{"signing_domain": "example.com", "selector": "s1", "dns_record": {"is_active": true}},
+				{"signing_domain": "example.com", "selector": "s2", "dns_record": {"is_active": false}},
+				{"signing_domain": "example.com", "selector": "s3", "dns_record": {"is_active": true}},
+				{"signing_domain": "example.com", "selector": "managed", "dns_record": {"is_active": true}}
+			]}`))
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/deactivate"):
+			mu.Lock()
+			deactivated = append(deactivated, strings.Split(r.URL.Path, "/")[5])
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"message": "success"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := (&Config{APIKey: "key", APIBaseURL: server.URL}).GetClient("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := activateDomainDKIMKey(context.Background(), client, "example.com", "s3", []string{"s2", "s1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deactivated) != 1 || deactivated[0] != "s1" {
+		t.Fatalf("expected only s1 to be deactivated, got %v", deactivated)
+	}
+}
+
+func TestAccMailgunDomain_DKIMRotation(t *testing.T) {
+	uuid, _ := uuid.GenerateUUID()
+	domain := fmt.Sprintf("terraform.%s.com", uuid)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunDomainConfigWith(domain, ""),
+			},
+			{
+				Config: testAccCheckMailgunDomainConfigWith(domain, `dkim_rotation_selector = "tfrotated"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "dkim_rotation_record.0.name", "tfrotated._domainkey."+domain),
+					resource.TestCheckResourceAttr(
+						"mailgun_domain.foobar", "dkim_rotation_record.0.record_type", "TXT"),
+					resource.TestCheckResourceAttrSet(
+						"mailgun_domain.foobar", "dkim_rotation_record.0.value"),
+				),
+			},
+			// The record isn't published, so the key must not be activated.
+			{
+				Config:   testAccCheckMailgunDomainConfigWith(domain, `dkim_rotation_selector = "tfrotated"`),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccMailgunDomain_Import(t *testing.T) {
 	resourceName := "mailgun_domain.foobar"
 	uuid, _ := uuid.GenerateUUID()
@@ -82,6 +447,26 @@ func TestAccMailgunDomain_Import(t *testing.T) {
 	})
 }
 
+func TestClickTrackingActive_UnmarshalJSON(t *testing.T) {
+	cases := map[string]clickTrackingActive{
+		`{"click": {"active": true}}`:       clickTrackingYes,
+		`{"click": {"active": false}}`:      clickTrackingNo,
+		`{"click": {"active": "htmlonly"}}`: clickTrackingHTMLOnly,
+		`{"click": {"active": "yes"}}`:      clickTrackingYes,
+	}
+
+	for data, expected := range cases {
+		var tracking domainTracking
+		if err := json.Unmarshal([]byte(data), &tracking); err != nil {
+			t.Fatalf("%s: unexpected error: %v", data, err)
+		}
+
+		if tracking.Click.Active != expected {
+			t.Fatalf("%s: expected %q, got %q", data, expected, tracking.Click.Active)
+		}
+	}
+}
+
 func testAccCheckMailgunDomainDestroy(s *terraform.State) error {
 
 	for _, rs := range s.RootModule().Resources {
@@ -89,7 +474,7 @@ func testAccCheckMailgunDomainDestroy(s *terraform.State) error {
 			continue
 		}
 
-		client, errc := testAccProvider.Meta().(*Config).GetClient(rs.Primary.Attributes["region"])
+		client, errc := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
 		if errc != nil {
 			return errc
 		}
@@ -147,7 +532,7 @@ func testAccCheckMailgunDomainExists(n string, DomainResp *mtypes.GetDomainRespo
 			return fmt.Errorf("No Domain ID is set")
 		}
 
-		client, errc := testAccProvider.Meta().(*Config).GetClient(rs.Primary.Attributes["region"])
+		client, errc := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
 		if errc != nil {
 			return errc
 		}
@@ -182,3 +567,36 @@ resource "mailgun_domain" "foobar" {
 	use_automatic_sender_security = true
 }`
 }
+
+func testAccCheckMailgunDomainConfigUpdated(domain string) string {
+	return `
+resource "mailgun_domain" "foobar" {
+    name = "` + domain + `"
+	spam_action = "tag"
+	region = "us"
+    wildcard = false
+	dkim_selector = "tfupdated"
+	force_dkim_authority = false
+	require_tls = true
+	skip_verification = true
+
+	unsubscribe_tracking {
+		active      = true
+		html_footer = "<p><a href=\"%unsubscribe_url%\">Unsubscribe</a></p>"
+		text_footer = "Unsubscribe: %unsubscribe_url%"
+	}
+	open_tracking = true
+	click_tracking_mode = "htmlonly"
+	web_scheme = "https"
+	use_automatic_sender_security = true
+}`
+}
+
+func testAccCheckMailgunDomainConfigWith(domain, extra string) string {
+	return `
+resource "mailgun_domain" "foobar" {
+    name = "` + domain + `"
+	region = "us"
+	` + extra + `
+}`
+}
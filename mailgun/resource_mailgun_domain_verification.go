@@ -3,7 +3,7 @@ package mailgun
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"log"
 	"strings"
 	"time"
 
@@ -64,12 +64,15 @@ func resourceMailgunDomainVerification() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"account": accountSchema("the domain verification"),
+
 			"region": {
 				Type:     schema.TypeString,
 				Optional: true,
-				Default:  "us",
+				Computed: true,
 				ForceNew: true,
 			},
+			"subaccount_id": subaccountSchema("the domain verification"),
 			"wait_for_active": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -110,7 +113,10 @@ func resourceMailgunDomainVerification() *schema.Resource {
 }
 
 func resourceMailgunDomainVerificationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -153,14 +159,15 @@ func resourceMailgunDomainVerificationCreate(ctx context.Context, d *schema.Reso
 }
 
 func resourceMailgunDomainVerificationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
 
 	resp, err := client.GetDomain(ctx, d.Id(), nil)
 	if err != nil {
-		if mailgun.GetStatusFromErr(err) == http.StatusNotFound {
+		if isNotFound(err) {
+			log.Printf("[WARN] Domain %s not found, removing domain verification from state", d.Id())
 			d.SetId("")
 			return nil
 		}
@@ -0,0 +1,205 @@
+package mailgun
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mailgun/mailgun-go/v5"
+)
+
+func resourceMailgunIPPool() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMailgunIPPoolCreate,
+		ReadContext:   resourceMailgunIPPoolRead,
+		UpdateContext: resourceMailgunIPPoolUpdate,
+		DeleteContext: resourceMailgunIPPoolDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMailgunIPPoolImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account": accountSchema("the IP pool"),
+
+			"region": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Computed: true,
+			},
+
+			"subaccount_id": subaccountSchema("the IP pool"),
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"ips": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Dedicated IPs in the pool.",
+			},
+
+			"replacement_pool_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the pool the linked domains move to when this pool is deleted. Mailgun refuses to delete a pool that is linked to domains without one.",
+			},
+
+			"pool_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"is_linked": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMailgunIPPoolImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+
+	setDefaultRegionForImport(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceMailgunIPPoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	name := d.Get("name").(string)
+	ips := expandStringSet(d.Get("ips").(*schema.Set))
+
+	log.Printf("[DEBUG] IP pool create configuration: name: %s, ips: %v", name, ips)
+
+	poolID, err := createIPPool(ctx, client, name, d.Get("description").(string), ips)
+	if err != nil {
+		return diag.Errorf("Error creating IP pool: %s", err)
+	}
+
+	d.SetId(poolID)
+
+	log.Printf("[INFO] IP pool ID: %s", d.Id())
+
+	// Retrieve and update state of IP pool
+	_, err = resourceMailgunIPPoolRetrieve(ctx, d.Id(), client, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMailgunIPPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	if d.HasChanges("name", "description", "ips") {
+		var name, description *string
+
+		if d.HasChange("name") {
+			v := d.Get("name").(string)
+			name = &v
+		}
+
+		if d.HasChange("description") {
+			v := d.Get("description").(string)
+			description = &v
+		}
+
+		o, n := d.GetChange("ips")
+		oldIPs, newIPs := o.(*schema.Set), n.(*schema.Set)
+		addIPs := expandStringSet(newIPs.Difference(oldIPs))
+		removeIPs := expandStringSet(oldIPs.Difference(newIPs))
+
+		log.Printf("[DEBUG] IP pool update configuration: add: %v, remove: %v", addIPs, removeIPs)
+
+		if err := updateIPPool(ctx, client, d.Id(), name, description, addIPs, removeIPs); err != nil {
+			return diag.Errorf("Error updating IP pool: %s", err)
+		}
+	}
+
+	// Retrieve and update state of IP pool
+	_, err := resourceMailgunIPPoolRetrieve(ctx, d.Id(), client, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMailgunIPPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	log.Printf("[INFO] Deleting IP pool: %s", d.Id())
+
+	err := deleteIPPool(ctx, client, d.Id(), d.Get("replacement_pool_id").(string))
+	if err != nil && !isNotFound(err) {
+		return diag.Errorf("Error deleting IP pool: %s", err)
+	}
+
+	return nil
+}
+
+func resourceMailgunIPPoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	_, err := resourceMailgunIPPoolRetrieve(ctx, d.Id(), client, d)
+
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] IP pool %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMailgunIPPoolRetrieve(ctx context.Context, id string, client *mailgun.Client, d *schema.ResourceData) (*ipPool, error) {
+
+	pool, err := getIPPool(ctx, client, id)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving IP pool: %w", err)
+	}
+
+	_ = d.Set("pool_id", id)
+	_ = d.Set("name", pool.Name)
+	_ = d.Set("description", pool.Description)
+	_ = d.Set("ips", pool.IPs)
+	_ = d.Set("is_linked", pool.IsLinked)
+
+	return pool, nil
+}
@@ -0,0 +1,91 @@
+package mailgun
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMailgunIPPool_Basic(t *testing.T) {
+	uuid, _ := uuid.GenerateUUID()
+	name := fmt.Sprintf("terraform-%s", uuid)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunIPPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunIPPoolConfig(name, "pool"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mailgun_ip_pool.foobar", "name", name),
+					resource.TestCheckResourceAttr("mailgun_ip_pool.foobar", "description", "pool"),
+					resource.TestCheckResourceAttrPair("mailgun_ip_pool.foobar", "pool_id", "mailgun_ip_pool.foobar", "id"),
+				),
+			},
+			{
+				Config: testAccCheckMailgunIPPoolConfig(name+"-renamed", "renamed pool"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mailgun_ip_pool.foobar", "name", name+"-renamed"),
+					resource.TestCheckResourceAttr("mailgun_ip_pool.foobar", "description", "renamed pool"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMailgunIPPool_Import(t *testing.T) {
+	uuid, _ := uuid.GenerateUUID()
+	name := fmt.Sprintf("terraform-%s", uuid)
+	resourceName := "mailgun_ip_pool.foobar"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunIPPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunIPPoolConfig(name, "pool"),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckMailgunIPPoolDestroy(s *terraform.State) error {
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mailgun_ip_pool" {
+			continue
+		}
+
+		client, errc := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
+		if errc != nil {
+			return errc
+		}
+
+		pool, err := getIPPool(context.Background(), client, rs.Primary.ID)
+
+		if err == nil {
+			return fmt.Errorf("IP pool still exists: %#v", pool)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckMailgunIPPoolConfig(name, description string) string {
+	return `
+resource "mailgun_ip_pool" "foobar" {
+	name        = "` + name + `"
+	description = "` + description + `"
+}`
+}
@@ -0,0 +1,196 @@
+package mailgun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mailgun/mailgun-go/v5"
+)
+
+func resourceMailgunIPWarmup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMailgunIPWarmupCreate,
+		ReadContext:   resourceMailgunIPWarmupRead,
+		DeleteContext: resourceMailgunIPWarmupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMailgunIPWarmupImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ip": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The dedicated IP to warm up.",
+			},
+
+			"account": accountSchema("the warmup"),
+
+			"subaccount_id": subaccountSchema("the warmup"),
+
+			"region": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Computed: true,
+			},
+
+			"completed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the warmup is over. Mailgun stops reporting the progress once it is.",
+			},
+
+			"stage_number": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"stage_start_volume": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"stage_volume_limit": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"stage_start_timestamp": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"sent_within_stage": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"throttle": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"last_updated_timestamp": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMailgunIPWarmupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+
+	setDefaultRegionForImport(d, meta)
+	setDefaultSubaccount(d, meta)
+	_ = d.Set("ip", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceMailgunIPWarmupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	ip := d.Get("ip").(string)
+
+	log.Printf("[INFO] Starting warmup of IP %s", ip)
+
+	if err := startIPWarmup(ctx, client, ip); err != nil {
+		return diag.Errorf("Error starting warmup of IP %s: %s", ip, err)
+	}
+
+	d.SetId(ip)
+
+	// Retrieve and update state of warmup
+	if err := resourceMailgunIPWarmupRetrieve(ctx, d.Id(), client, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMailgunIPWarmupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("completed").(bool) {
+		return nil
+	}
+
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	log.Printf("[INFO] Cancelling warmup of IP %s", d.Id())
+
+	if err := cancelIPWarmup(ctx, client, d.Id()); err != nil && !isNotFound(err) {
+		return diag.Errorf("Error cancelling warmup of IP %s: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func resourceMailgunIPWarmupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	if err := resourceMailgunIPWarmupRetrieve(ctx, d.Id(), client, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceMailgunIPWarmupRetrieve reads the warmup progress. Mailgun doesn't
+// tell a finished warmup from one that was never started, so a warmup that
+// disappears is kept in state as completed instead of being started again.
+func resourceMailgunIPWarmupRetrieve(ctx context.Context, id string, client *mailgun.Client, d *schema.ResourceData) error {
+
+	warmup, err := getIPWarmup(ctx, client, id)
+
+	if err != nil {
+		if !isNotFound(err) {
+			return fmt.Errorf("Error retrieving warmup of IP %s: %w", id, err)
+		}
+
+		log.Printf("[INFO] IP %s is no longer warming up, marking the warmup completed", id)
+
+		_ = d.Set("ip", id)
+		_ = d.Set("completed", true)
+
+		return nil
+	}
+
+	_ = d.Set("ip", id)
+	_ = d.Set("completed", false)
+	_ = d.Set("stage_number", jsonNumberToInt(warmup.StageNumber))
+	_ = d.Set("stage_start_volume", jsonNumberToInt(warmup.StageStartVolume))
+	_ = d.Set("stage_volume_limit", jsonNumberToInt(warmup.StageVolumeLimit))
+	_ = d.Set("stage_start_timestamp", warmup.StageStartTimestamp)
+	_ = d.Set("sent_within_stage", jsonNumberToInt(warmup.SentWithinStage))
+	_ = d.Set("throttle", jsonNumberToInt(warmup.Throttle))
+	_ = d.Set("last_updated_timestamp", warmup.LastUpdatedTimestamp)
+
+	return nil
+}
+
+// jsonNumberToInt converts numbers of the warmup API, which are sometimes
+// sent as strings, to integers. Missing numbers become 0.
+func jsonNumberToInt(n json.Number) int {
+	v, err := n.Int64()
+	if err != nil {
+		return 0
+	}
+
+	return int(v)
+}
@@ -0,0 +1,127 @@
+package mailgun
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mailgun/mailgun-go/v5"
+)
+
+func TestAccMailgunIPWarmup_Basic(t *testing.T) {
+	ip := os.Getenv("MAILGUN_TEST_WARMUP_IP")
+	if ip == "" {
+		t.Skip("MAILGUN_TEST_WARMUP_IP must be set to a dedicated IP that isn't warming up")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunIPWarmupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "mailgun_ip_warmup" "foobar" {
+	ip = %q
+}`, ip),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mailgun_ip_warmup.foobar", "ip", ip),
+					resource.TestCheckResourceAttr("mailgun_ip_warmup.foobar", "completed", "false"),
+					resource.TestCheckResourceAttrSet("mailgun_ip_warmup.foobar", "stage_number"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceMailgunIPWarmupRetrieve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v3/ips/10.0.0.1/warmups":
+			_, _ = w.Write([]byte(`{"details": {"ip": "10.0.0.1", "sent_within_stage": "150", "throttle": 20, "stage_number": 2, "stage_start_volume": 100, "stage_volume_limit": 500, "stage_start_timestamp": "2024-01-02T00:00:00Z"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := (&Config{APIKey: "key", APIBaseURL: server.URL}).GetClient("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceMailgunIPWarmup().Schema, map[string]interface{}{})
+
+	if err := resourceMailgunIPWarmupRetrieve(context.Background(), "10.0.0.1", client, d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if d.Get("completed").(bool) || d.Get("stage_number").(int) != 2 || d.Get("sent_within_stage").(int) != 150 || d.Get("stage_volume_limit").(int) != 500 {
+		t.Fatalf("unexpected warmup state: %#v", d.State())
+	}
+
+	// Finished warmups are no longer reported.
+	if err := resourceMailgunIPWarmupRetrieve(context.Background(), "10.0.0.2", client, d); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !d.Get("completed").(bool) {
+		t.Fatal("expected the warmup to be completed")
+	}
+}
+
+func TestResourceMailgunIPWarmupRead_StoredSubaccount(t *testing.T) {
+	subaccounts := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subaccounts <- r.Header.Get(mailgun.OnBehalfOfHeader)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"details": {"ip": "10.0.0.1", "stage_number": 1}}`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceMailgunIPWarmup().Schema, map[string]interface{}{
+		"ip":            "10.0.0.1",
+		"region":        "us",
+		"subaccount_id": "warmup-sub",
+	})
+	d.SetId("10.0.0.1")
+
+	diags := resourceMailgunIPWarmupRead(context.Background(), d, &Config{APIKey: "key", APIBaseURL: server.URL, SubaccountID: "provider-sub"})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if subaccount := <-subaccounts; subaccount != "warmup-sub" {
+		t.Fatalf("expected the warmup to be read on behalf of warmup-sub, got %q", subaccount)
+	}
+}
+
+func testAccCheckMailgunIPWarmupDestroy(s *terraform.State) error {
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mailgun_ip_warmup" {
+			continue
+		}
+
+		client, errc := testAccProvider.Meta().(*Config).GetClient(rs.Primary.Attributes["region"])
+		if errc != nil {
+			return errc
+		}
+
+		warmup, err := getIPWarmup(context.Background(), client, rs.Primary.ID)
+
+		if err == nil {
+			return fmt.Errorf("IP warmup still exists: %#v", warmup)
+		}
+	}
+
+	return nil
+}
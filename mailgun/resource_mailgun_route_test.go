@@ -65,7 +65,7 @@ func testAccCheckMailgunRouteDestroy(s *terraform.State) error {
 			continue
 		}
 
-		client, _ := testAccProvider.Meta().(*Config).GetClient(rs.Primary.Attributes["region"])
+		client, _ := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
 
 		route, err := client.GetRoute(context.Background(), rs.Primary.ID)
 
@@ -89,11 +89,14 @@ func testAccCheckMailgunRouteExists(n string, Route *mtypes.Route) resource.Test
 			return fmt.Errorf("No Route ID is set")
 		}
 
-		client := testAccProvider.Meta().(*Config)
+		client, errc := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
+		if errc != nil {
+			return errc
+		}
 
 		err := resource.RetryContext(context.Background(), 1*time.Minute, func() *resource.RetryError {
 			var err error
-			*Route, err = client.MailgunClient.GetRoute(context.Background(), rs.Primary.ID)
+			*Route, err = client.GetRoute(context.Background(), rs.Primary.ID)
 
 			if err != nil {
 				return resource.NonRetryableError(err)
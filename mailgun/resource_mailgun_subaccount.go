@@ -0,0 +1,166 @@
+package mailgun
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mailgun/mailgun-go/v5/mtypes"
+)
+
+const subaccountStatusDisabled = "disabled"
+
+func resourceMailgunSubaccount() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMailgunSubaccountCreate,
+		ReadContext:   resourceMailgunSubaccountRead,
+		UpdateContext: resourceMailgunSubaccountUpdate,
+		DeleteContext: resourceMailgunSubaccountDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMailgunSubaccountImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"account": accountSchema("the subaccount"),
+
+			"region": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the subaccount is enabled. Toggling it disables or enables the subaccount in place.",
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceMailgunSubaccountImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	setDefaultRegionForImport(d, meta)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceMailgunSubaccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	setDefaultRegion(d, meta)
+
+	client, errc := accountClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Subaccount create configuration: name: %s", name)
+
+	resp, err := client.CreateSubaccount(ctx, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resp.Item.ID)
+
+	log.Printf("[INFO] Subaccount ID: %s", d.Id())
+
+	if !d.Get("enabled").(bool) {
+		if _, err := client.DisableSubaccount(ctx, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceMailgunSubaccountRead(ctx, d, meta)
+}
+
+func resourceMailgunSubaccountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := accountClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	if d.HasChange("enabled") {
+		var err error
+		if d.Get("enabled").(bool) {
+			log.Printf("[INFO] Enabling subaccount: %s", d.Id())
+			_, err = client.EnableSubaccount(ctx, d.Id())
+		} else {
+			log.Printf("[INFO] Disabling subaccount: %s", d.Id())
+			_, err = client.DisableSubaccount(ctx, d.Id())
+		}
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceMailgunSubaccountRead(ctx, d, meta)
+}
+
+func resourceMailgunSubaccountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := accountClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	// Mailgun has no API to delete a subaccount, so the closest equivalent
+	// is to disable it before forgetting about it.
+	log.Printf("[INFO] Disabling subaccount on delete: %s", d.Id())
+
+	if d.Get("status").(string) != subaccountStatusDisabled {
+		if _, err := client.DisableSubaccount(ctx, d.Id()); err != nil {
+			return diag.Errorf("Error disabling subaccount: %s", err)
+		}
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Subaccount disabled, not deleted",
+			Detail:   "Mailgun does not support deleting subaccounts. Subaccount " + d.Id() + " was disabled and removed from the Terraform state.",
+		},
+	}
+}
+
+func resourceMailgunSubaccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := accountClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	resp, err := client.GetSubaccount(ctx, d.Id())
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Subaccount %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	setSubaccountResourceState(d, resp.Item)
+
+	return nil
+}
+
+func setSubaccountResourceState(d *schema.ResourceData, subaccount mtypes.Subaccount) {
+	_ = d.Set("name", subaccount.Name)
+	_ = d.Set("status", subaccount.Status)
+	_ = d.Set("enabled", subaccount.Status != subaccountStatusDisabled)
+}
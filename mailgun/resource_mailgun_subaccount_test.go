@@ -0,0 +1,120 @@
+package mailgun
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMailgunSubaccount_Basic(t *testing.T) {
+	uuid, _ := uuid.GenerateUUID()
+	name := fmt.Sprintf("terraform-%s", uuid[:8])
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunSubaccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunSubaccountConfig(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMailgunSubaccountStatus("mailgun_subaccount.foobar", "enabled"),
+					resource.TestCheckResourceAttr(
+						"mailgun_subaccount.foobar", "name", name),
+					resource.TestCheckResourceAttr(
+						"mailgun_subaccount.foobar", "enabled", "true"),
+					resource.TestCheckResourceAttrSet(
+						"mailgun_subaccount.foobar", "id"),
+				),
+			},
+			{
+				Config: testAccCheckMailgunSubaccountConfig(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMailgunSubaccountStatus("mailgun_subaccount.foobar", "disabled"),
+					resource.TestCheckResourceAttr(
+						"mailgun_subaccount.foobar", "enabled", "false"),
+					resource.TestCheckResourceAttr(
+						"mailgun_subaccount.foobar", "status", "disabled"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMailgunSubaccount_Import(t *testing.T) {
+	resourceName := "mailgun_subaccount.foobar"
+	uuid, _ := uuid.GenerateUUID()
+	name := fmt.Sprintf("terraform-%s", uuid[:8])
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunSubaccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunSubaccountConfig(name, true),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Subaccounts can't be deleted, so destroying one only disables it.
+func testAccCheckMailgunSubaccountDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mailgun_subaccount" {
+			continue
+		}
+
+		if err := testAccCheckMailgunSubaccountStatus(rs.Primary.ID, "disabled")(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckMailgunSubaccountStatus(n string, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		var id, region string
+
+		if rs, ok := s.RootModule().Resources[n]; ok {
+			id = rs.Primary.ID
+			region = rs.Primary.Attributes["region"]
+		} else {
+			id = n
+		}
+
+		client, errc := testAccProvider.Meta().(*Config).GetAccountClient(region)
+		if errc != nil {
+			return errc
+		}
+
+		resp, err := client.GetSubaccount(context.Background(), id)
+		if err != nil {
+			return err
+		}
+
+		if resp.Item.Status != status {
+			return fmt.Errorf("Bad subaccount status: %s", resp.Item.Status)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMailgunSubaccountConfig(name string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "mailgun_subaccount" "foobar" {
+	name    = "%s"
+	enabled = %t
+}`, name, enabled)
+}
@@ -0,0 +1,305 @@
+package mailgun
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mailgun/mailgun-go/v5"
+	"github.com/mailgun/mailgun-go/v5/mtypes"
+)
+
+func resourceMailgunTemplate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMailgunTemplateCreate,
+		ReadContext:   resourceMailgunTemplateRead,
+		UpdateContext: resourceMailgunTemplateUpdate,
+		DeleteContext: resourceMailgunTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMailgunTemplateImport,
+		},
+
+		CustomizeDiff: customizeDiffTemplateVersion,
+
+		Schema: map[string]*schema.Schema{
+			"account": accountSchema("the template"),
+
+			"region": {
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Computed: true,
+			},
+
+			"subaccount_id": subaccountSchema("the template"),
+
+			"domain": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"template": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Content of the active version. Changing it adds a new version and activates it.",
+			},
+
+			"engine": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(mtypes.TemplateEngineHandlebars), string(mtypes.TemplateEngineGo)}, false),
+			},
+
+			"tag": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Tag of the active version. Generated when not set.",
+			},
+
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Headers of the active version, such as `From`, `Subject` and `Reply-To`.",
+			},
+		},
+	}
+}
+
+// customizeDiffTemplateVersion plans the tag of the version added when the
+// content changes. Mailgun tags are unique within a template, so a configured
+// tag has to change along with the content.
+func customizeDiffTemplateVersion(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChanges("template", "engine", "headers") {
+		return nil
+	}
+
+	config := diff.GetRawConfig()
+	if !config.IsNull() && !config.GetAttr("tag").IsNull() {
+		if !diff.HasChange("tag") {
+			return fmt.Errorf("tag must be changed along with the template content, as it names the new version")
+		}
+
+		return nil
+	}
+
+	return diff.SetNewComputed("tag")
+}
+
+func resourceMailgunTemplateImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if strings.Count(d.Id(), ":") == 2 {
+		setDefaultRegionForImport(d, meta)
+	} else {
+		setAccountForImport(d, meta)
+		setDefaultRegion(d, meta)
+	}
+	setDefaultSubaccount(d, meta)
+
+	domain, name, err := parseTemplateID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	_ = d.Set("domain", domain)
+	_ = d.Set("name", name)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceMailgunTemplateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	domain := d.Get("domain").(string)
+	name := d.Get("name").(string)
+	version := expandTemplateVersion(d)
+
+	log.Printf("[DEBUG] Template create configuration: domain: %s, name: %s, tag: %s", domain, name, version.Tag)
+
+	err := createTemplate(ctx, client, domain, name, d.Get("description").(string), version)
+	if err != nil {
+		return diag.Errorf("Error creating template: %s", err)
+	}
+
+	d.SetId(domain + ":" + name)
+
+	log.Printf("[INFO] Template ID: %s", d.Id())
+
+	// Retrieve and update state of template
+	_, err = resourceMailgunTemplateRetrieve(ctx, d.Id(), client, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMailgunTemplateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	domain := d.Get("domain").(string)
+	name := d.Get("name").(string)
+
+	if d.HasChange("description") {
+		errc = updateTemplateDescription(ctx, client, domain, name, d.Get("description").(string))
+
+		if errc != nil {
+			return diag.Errorf("Error updating template: %s", errc)
+		}
+	}
+
+	if d.HasChanges("template", "engine", "headers", "tag") {
+		version := expandTemplateVersion(d)
+		if version.Tag == "" {
+			version.Tag = id.PrefixedUniqueId("v")
+		}
+
+		log.Printf("[INFO] Adding version %s to template %s", version.Tag, d.Id())
+
+		errc = addTemplateVersion(ctx, client, domain, name, version)
+
+		if errc != nil {
+			return diag.Errorf("Error adding template version: %s", errc)
+		}
+	} else if d.HasChange("comment") {
+		errc = updateTemplateVersionComment(ctx, client, domain, name, d.Get("tag").(string), d.Get("comment").(string))
+
+		if errc != nil {
+			return diag.Errorf("Error updating template version: %s", errc)
+		}
+	}
+
+	// Retrieve and update state of template
+	_, err := resourceMailgunTemplateRetrieve(ctx, d.Id(), client, d)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMailgunTemplateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	log.Printf("[INFO] Deleting template: %s", d.Id())
+
+	err := client.DeleteTemplate(ctx, d.Get("domain").(string), d.Get("name").(string))
+	if err != nil && !isNotFound(err) {
+		return diag.Errorf("Error deleting template: %s", err)
+	}
+
+	return nil
+}
+
+func resourceMailgunTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, errc := resourceClient(d, meta)
+	if errc != nil {
+		return diag.FromErr(errc)
+	}
+
+	_, err := resourceMailgunTemplateRetrieve(ctx, d.Id(), client, d)
+
+	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Template %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMailgunTemplateRetrieve(ctx context.Context, id string, client *mailgun.Client, d *schema.ResourceData) (*template, error) {
+	domain, name, err := parseTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := getTemplate(ctx, client, domain, name)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving template: %w", err)
+	}
+
+	_ = d.Set("domain", domain)
+	_ = d.Set("name", tmpl.Name)
+	_ = d.Set("description", tmpl.Description)
+	_ = d.Set("template", tmpl.Version.Template)
+	_ = d.Set("engine", string(tmpl.Version.Engine))
+	_ = d.Set("tag", tmpl.Version.Tag)
+	_ = d.Set("comment", tmpl.Version.Comment)
+	_ = d.Set("headers", tmpl.Version.Headers)
+
+	return tmpl, nil
+}
+
+// expandTemplateVersion returns the configured template version.
+func expandTemplateVersion(d *schema.ResourceData) *templateVersion {
+	version := &templateVersion{}
+
+	version.Template = d.Get("template").(string)
+	version.Engine = mtypes.TemplateEngine(d.Get("engine").(string))
+	version.Tag = d.Get("tag").(string)
+	version.Comment = d.Get("comment").(string)
+
+	headers := d.Get("headers").(map[string]interface{})
+	if len(headers) > 0 {
+		version.Headers = make(map[string]string, len(headers))
+		for k, v := range headers {
+			version.Headers[k] = v.(string)
+		}
+	}
+
+	return version
+}
+
+// parseTemplateID splits a domain:name ID.
+func parseTemplateID(id string) (string, string, error) {
+	parts := strings.SplitN(id, ":", 2)
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid template ID %q, expected domain:name", id)
+	}
+
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,150 @@
+package mailgun
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccMailgunTemplate_Basic(t *testing.T) {
+	uuid, _ := uuid.GenerateUUID()
+	domain := fmt.Sprintf("terraform.%s.com", uuid)
+
+	var firstTag string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunTemplateConfig(domain, "<p>Hello {{name}}</p>", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mailgun_template.foobar", "id", domain+":welcome"),
+					resource.TestCheckResourceAttr("mailgun_template.foobar", "template", "<p>Hello {{name}}</p>"),
+					resource.TestCheckResourceAttr("mailgun_template.foobar", "engine", "handlebars"),
+					resource.TestCheckResourceAttr("mailgun_template.foobar", "headers.Subject", "Welcome"),
+					resource.TestCheckResourceAttrWith("mailgun_template.foobar", "tag", func(value string) error {
+						firstTag = value
+						return nil
+					}),
+				),
+			},
+			// Changing the content adds a version instead of replacing the
+			// template.
+			{
+				Config: testAccCheckMailgunTemplateConfig(domain, "<p>Hi {{name}}</p>", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mailgun_template.foobar", "id", domain+":welcome"),
+					resource.TestCheckResourceAttr("mailgun_template.foobar", "template", "<p>Hi {{name}}</p>"),
+					resource.TestCheckResourceAttrWith("mailgun_template.foobar", "tag", func(value string) error {
+						if value == "" || value == firstTag {
+							return fmt.Errorf("expected a new version tag, got %q", value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccCheckMailgunTemplateConfig(domain, "<p>Hi {{name}}</p>", `tag = "v3"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("mailgun_template.foobar", "tag", "v3"),
+				),
+			},
+			{
+				Config:      testAccCheckMailgunTemplateConfig(domain, "<p>Hey {{name}}</p>", `tag = "v3"`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("tag must be changed along with the template content"),
+			},
+		},
+	})
+}
+
+func TestAccMailgunTemplate_Import(t *testing.T) {
+	resourceName := "mailgun_template.foobar"
+	uuid, _ := uuid.GenerateUUID()
+	domain := fmt.Sprintf("terraform.%s.com", uuid)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: newProvider(),
+		CheckDestroy:      testAccCheckMailgunTemplateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckMailgunTemplateConfig(domain, "<p>Hello {{name}}</p>", ""),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "us:" + domain + ":welcome",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseTemplateID(t *testing.T) {
+	t.Parallel()
+
+	domain, name, err := parseTemplateID("example.com:welcome")
+	if err != nil || domain != "example.com" || name != "welcome" {
+		t.Fatalf("unexpected result %q, %q, %v", domain, name, err)
+	}
+
+	for _, id := range []string{"example.com", ":welcome", "example.com:"} {
+		if _, _, err := parseTemplateID(id); err == nil {
+			t.Fatalf("%q: expected an error", id)
+		}
+	}
+}
+
+func testAccCheckMailgunTemplateDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "mailgun_template" {
+			continue
+		}
+
+		client, errc := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
+		if errc != nil {
+			return errc
+		}
+
+		_, err := getTemplate(context.Background(), client, rs.Primary.Attributes["domain"], rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("Template still exists: %s", rs.Primary.ID)
+		}
+		if !isNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckMailgunTemplateConfig(domain, body, extra string) string {
+	return fmt.Sprintf(`
+resource "mailgun_domain" "foobar" {
+	name   = "%s"
+	region = "us"
+}
+
+resource "mailgun_template" "foobar" {
+	domain      = mailgun_domain.foobar.name
+	region      = "us"
+	name        = "welcome"
+	description = "Welcome message"
+	template    = %q
+	comment     = "managed by terraform"
+
+	headers = {
+		Subject = "Welcome"
+	}
+
+	%s
+}`, domain, body, extra)
+}
@@ -22,13 +22,17 @@ func resourceMailgunWebhook() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"account": accountSchema("the webhook"),
+
 			"region": {
 				Type:     schema.TypeString,
 				ForceNew: true,
 				Optional: true,
-				Default:  "us",
+				Computed: true,
 			},
 
+			"subaccount_id": subaccountSchema("the webhook"),
+
 			"domain": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -65,13 +69,17 @@ func resourceMailgunWebhook() *schema.Resource {
 }
 
 func resourceMailgunWebhookImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	setDefaultRegionForImport(d)
+	setDefaultRegionForImport(d, meta)
+	setDefaultSubaccount(d, meta)
 
 	return []*schema.ResourceData{d}, nil
 }
 
 func resourceMailgunWebhookCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	setDefaultRegion(d, meta)
+	setDefaultSubaccount(d, meta)
+
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -98,7 +106,7 @@ func resourceMailgunWebhookCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceMailgunWebhookUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -122,7 +130,7 @@ func resourceMailgunWebhookUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceMailgunWebhookDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -140,7 +148,7 @@ func resourceMailgunWebhookDelete(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceMailgunWebhookRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, errc := meta.(*Config).GetClient(d.Get("region").(string))
+	client, errc := resourceClient(d, meta)
 	if errc != nil {
 		return diag.FromErr(errc)
 	}
@@ -148,6 +156,12 @@ func resourceMailgunWebhookRead(ctx context.Context, d *schema.ResourceData, met
 	kind := d.Get("kind").(string)
 	urls, err := client.GetWebhook(ctx, d.Get("domain").(string), kind)
 	if err != nil {
+		if isNotFound(err) {
+			log.Printf("[WARN] Webhook %s not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+
 		return diag.FromErr(err)
 	}
 
@@ -68,7 +68,7 @@ func testAccCheckMailgunWebhookDestroy(s *terraform.State) error {
 			continue
 		}
 
-		client, _ := testAccProvider.Meta().(*Config).GetClient(rs.Primary.Attributes["region"])
+		client, _ := testAccProvider.Meta().(*Config).GetSubaccountClient(rs.Primary.Attributes["region"], rs.Primary.Attributes["subaccount_id"])
 
 		kind := rs.Primary.Attributes["kind"]
 		webhooks, err := client.GetWebhook(context.Background(), rs.Primary.Attributes["domain"], kind)
@@ -0,0 +1,339 @@
+package mailgun
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/mailgun/mailgun-go/v5"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryMinWait = 1 * time.Second
+	defaultRetryMaxWait = 30 * time.Second
+	defaultBurst        = 1
+
+	// maxLoggedBodySize caps request and response bodies written to the
+	// debug log.
+	maxLoggedBodySize = 16 * 1024
+)
+
+// retryTransport retries requests that Mailgun rejected because of rate
+// limiting (429), which happens before anything is changed, or idempotent
+// requests that failed with a transient server error (5xx). Other requests
+// aren't retried on 5xx, as the first attempt may have created something
+// already. The wait between attempts
+// grows exponentially from minWait up to maxWait, unless the response carries
+// a Retry-After header, which is honored up to maxWait.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || attempt >= t.maxRetries || !shouldRetryResponse(req, resp) {
+			return resp, err
+		}
+
+		if req.Body != nil && req.GetBody == nil {
+			// The body has been consumed and can't be replayed.
+			return resp, nil
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		log.Printf("[WARN] Mailgun API returned %d for %s %s, retrying in %s (attempt %d/%d)",
+			resp.StatusCode, req.Method, req.URL.Path, wait, attempt+1, t.maxRetries)
+
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		if wait > t.maxWait {
+			return t.maxWait
+		}
+		return wait
+	}
+
+	wait := t.minWait << uint(attempt)
+	if wait <= 0 || wait > t.maxWait {
+		return t.maxWait
+	}
+
+	return wait
+}
+
+func shouldRetryResponse(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return isIdempotent(req.Method) && resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+
+	return false
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: a number
+// of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// rateLimitTransport delays requests so that no more than the configured
+// number of requests per second reach Mailgun. The limiter is shared by every
+// client built from the same Config, and therefore by all goroutines.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait := t.limiter.reserve(); wait > 0 {
+		log.Printf("[TRACE] Throttling Mailgun request %s %s for %s", req.Method, req.URL.Path, wait)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// rateLimiter is a token bucket holding up to burst tokens and refilled at
+// rate tokens per second. Tokens may go negative: every caller reserves its
+// token immediately and waits until the bucket would have refilled it.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait before
+// using it.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// timeoutTransport bounds a single attempt, from dialing until the response
+// body is closed, by timeout. It sits below retryTransport, so every retry
+// gets a fresh timeout.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelOnCloseBody releases the timeout of a response once its body is
+// closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// onBehalfOfTransport sends every request on behalf of a Mailgun subaccount.
+type onBehalfOfTransport struct {
+	next         http.RoundTripper
+	subaccountID string
+}
+
+func (t *onBehalfOfTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(mailgun.OnBehalfOfHeader, t.subaccountID)
+
+	return t.next.RoundTrip(req)
+}
+
+// loggingTransport writes every Mailgun request and response to the
+// provider log through tflog, so the output follows TF_LOG and
+// TF_LOG_PROVIDER. Headers are never logged, which keeps the basic-auth API
+// key out of the log, and secrets in bodies are redacted.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	fields := map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.String(),
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxLoggedBodySize))
+			_ = body.Close()
+			fields["request_body"] = redactSecrets(string(data))
+		}
+	}
+
+	tflog.Debug(ctx, "Sending Mailgun API request", fields)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Mailgun API request failed", fields)
+		return resp, err
+	}
+
+	delete(fields, "request_body")
+	fields["status"] = resp.StatusCode
+
+	data, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxLoggedBodySize {
+		data = data[:maxLoggedBodySize]
+	}
+	fields["response_body"] = redactSecrets(string(data))
+
+	tflog.Debug(ctx, "Received Mailgun API response", fields)
+
+	return resp, nil
+}
+
+// secretFieldPatterns match the values of the password, smtp_password,
+// secret, pem and private_key fields in the body formats the Mailgun API
+// uses.
+var secretFieldPatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	// application/x-www-form-urlencoded: password=...&
+	{regexp.MustCompile(`((?:^|&)(?:smtp_password|password|secret|pem|private_key)=)[^&]*`), "${1}***"},
+	// application/json: "password": "..."
+	{regexp.MustCompile(`("(?:smtp_password|password|secret|pem|private_key)"\s*:\s*)"(?:[^"\\]|\\.)*"`), `${1}"***"`},
+	// multipart/form-data: name="password"\r\n\r\n...
+	{regexp.MustCompile(`(name="(?:smtp_password|password|secret|pem|private_key)"\r\n\r\n)[^\r]*`), "${1}***"},
+}
+
+// redactSecrets masks secret field values in a request or response body.
+func redactSecrets(body string) string {
+	for _, p := range secretFieldPatterns {
+		body = p.re.ReplaceAllString(body, p.replacement)
+	}
+
+	return body
+}